@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/canyouhack/steg-cli/pkg/imganalysis"
+	"github.com/spf13/cobra"
+)
+
+// bitplanesCmd is an internal entry point the "bitplanes" tool execs into
+// via the running binary itself (see runner.selfBinary), so native bitplane
+// extraction has no external dependency the way the Python/Pillow script it
+// replaced did. It's hidden from --help since it's not meant to be run
+// directly.
+var bitplanesCmd = &cobra.Command{
+	Use:    "__bitplanes <file> <outDir>",
+	Hidden: true,
+	Args:   cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		report, err := imganalysis.Analyze(args[0], args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		printBitplaneReport(report)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bitplanesCmd)
+}
+
+func printBitplaneReport(report *imganalysis.Report) {
+	fmt.Printf("Mode: %s (%dx%d), %d bitplanes written\n\n", report.Mode, report.Width, report.Height, len(report.Planes))
+
+	fmt.Println("Chi-square LSB-of-pairs test (Westfeld-Pfitzmann):")
+	for _, ch := range report.Channels {
+		note := ""
+		if ch.Suspicious {
+			note = "  [!] SUSPICIOUS: value pairs look equalized, consistent with LSB embedding"
+		}
+		fmt.Printf("  %-8s chi2=%.2f  p=%.4f%s\n", ch.Channel, ch.ChiSquare, ch.PValue, note)
+	}
+
+	fmt.Println("\nBitplane entropy:")
+	for _, p := range report.Planes {
+		note := ""
+		if p.Suspicious {
+			note = "  [!] near-random"
+		}
+		fmt.Printf("  %-8s bit%d  entropy=%.4f  %s%s\n", p.Channel, p.Bit, p.Entropy, p.File, note)
+	}
+}
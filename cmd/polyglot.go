@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/canyouhack/steg-cli/pkg/polyglot"
+	"github.com/spf13/cobra"
+)
+
+// polyglotCmd is an internal entry point the "polyglot" tool execs into via
+// the running binary itself (see runner.selfBinary), mirroring
+// __bitplanes. It's hidden from --help since it's not meant to be run
+// directly.
+var polyglotCmd = &cobra.Command{
+	Use:    "__polyglot <file> <outDir>",
+	Hidden: true,
+	Args:   cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		filePath, outDir := args[0], args[1]
+
+		findings, err := polyglot.Scan(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(findings) == 0 {
+			fmt.Println("No appended archive signatures detected.")
+			return
+		}
+
+		for _, f := range findings {
+			fmt.Printf("[!] %s signature at offset %d (%d trailing bytes)\n", f.Format, f.Offset, f.TrailingBytes)
+			for _, entry := range f.Entries {
+				fmt.Printf("    %s\n", entry)
+			}
+
+			written, err := polyglot.Extract(filePath, f, filepath.Join(outDir, f.Format))
+			if err != nil {
+				fmt.Printf("    error extracting: %v\n", err)
+				continue
+			}
+			for _, path := range written {
+				fmt.Printf("    extracted -> %s\n", path)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(polyglotCmd)
+}
@@ -3,7 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"strings"
+	"runtime"
+	"time"
 
 	"github.com/canyouhack/steg-cli/pkg/deps"
 	"github.com/canyouhack/steg-cli/pkg/detector"
@@ -14,13 +15,20 @@ import (
 )
 
 var (
-	password  string
-	skipTools []string
-	onlyTools []string
-	outputDir string
-	verbose   bool
-	noInstall bool
-	timeout   int
+	password     string
+	skipTools    []string
+	onlyTools    []string
+	outputDir    string
+	verbose      bool
+	noInstall    bool
+	timeout      int
+	jobs         int
+	includeGlobs []string
+	excludeGlobs []string
+	skipUnknown  bool
+	format       string
+	outputFile   string
+	maxDepth     int
 )
 
 var rootCmd = &cobra.Command{
@@ -36,15 +44,34 @@ Tools: exiftool, binwalk, foremost, steghide, outguess, zsteg, pngcheck,
 }
 
 var scanCmd = &cobra.Command{
-	Use:   "scan <file>",
-	Short: "Scan a file for hidden steganographic data",
-	Long:  "Run all applicable steganography tools against the given file and display results.",
+	Use:   "scan <file|dir>",
+	Short: "Scan a file or directory for hidden steganographic data",
+	Long:  "Run all applicable steganography tools against the given file, or every matching file in a directory, and display results.",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		filePath := args[0]
 
+		resolvedFormat := format
+		if resolvedFormat == "" {
+			if stdoutIsTTY() {
+				resolvedFormat = "pretty"
+			} else {
+				resolvedFormat = "json"
+			}
+		}
+
+		if resolvedFormat != "pretty" {
+			runStructuredScan(filePath, resolvedFormat)
+			return
+		}
+
 		output.PrintBanner()
 
+		if stat, err := os.Stat(filePath); err == nil && stat.IsDir() {
+			runBatchScan(filePath)
+			return
+		}
+
 		// Detect file type
 		fileInfo, err := detector.Detect(filePath)
 		if err != nil {
@@ -71,23 +98,18 @@ var scanCmd = &cobra.Command{
 
 		// Run scan
 		opts := runner.RunOpts{
-			Password:  password,
-			Verbose:   verbose,
-			OutputDir: outputDir,
-			Skip:      skipTools,
-			Only:      onlyTools,
-			Timeout:   60 * 1e9, // 60s in nanoseconds
-		}
-
-		if timeout > 0 {
-			opts.Timeout = runner.RunOpts{}.Timeout // use default
+			Password:       password,
+			Verbose:        verbose,
+			OutputDir:      outputDir,
+			Skip:           skipTools,
+			Only:           onlyTools,
+			PerToolTimeout: time.Duration(timeout) * time.Second,
+			Concurrency:    jobs,
+			MaxDepth:       maxDepth,
 		}
 
 		scanResult := runner.RunAll(fileInfo, opts)
 
-		// Clear progress line
-		fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
-
 		// Print results
 		runner.PrintResults(scanResult)
 
@@ -102,6 +124,134 @@ var scanCmd = &cobra.Command{
 	},
 }
 
+// runBatchScan scans every matching file under dir concurrently and prints
+// a per-file summary followed by an aggregate total.
+func runBatchScan(dir string) {
+	opts := runner.BatchOpts{
+		RunOpts: runner.RunOpts{
+			Password:       password,
+			Verbose:        verbose,
+			OutputDir:      outputDir,
+			Skip:           skipTools,
+			Only:           onlyTools,
+			PerToolTimeout: time.Duration(timeout) * time.Second,
+			MaxDepth:       maxDepth,
+		},
+		Jobs:        jobs,
+		Include:     includeGlobs,
+		Exclude:     excludeGlobs,
+		SkipUnknown: skipUnknown,
+	}
+
+	color.New(color.FgYellow, color.Bold).Printf("  🔍 Batch scanning %s with %d jobs...\n\n", dir, jobs)
+
+	results := runner.BatchRun(dir, opts)
+
+	scanned, errored := 0, 0
+	for _, r := range results {
+		if r.Error != nil {
+			errored++
+			color.New(color.FgRed).Printf("  ✗ %s: %v\n", r.Path, r.Error)
+			continue
+		}
+		if r.Scan == nil {
+			continue
+		}
+		scanned++
+		output.PrintFileInfo(r.Scan.FileInfo.Name, r.Scan.FileInfo.Path, r.Scan.FileInfo.MimeType, r.Scan.FileInfo.Size, string(r.Scan.FileInfo.Category))
+		runner.PrintResults(r.Scan)
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	cyan.Printf("  📊 Batch complete: %d scanned, %d errored, %d total files seen\n\n", scanned, errored, len(results))
+}
+
+// runStructuredScan runs a single-file scan through the named Reporter
+// ("json" or "sarif") instead of the colorized console renderer, writing to
+// --output-file if set or stdout otherwise.
+func runStructuredScan(filePath, format string) {
+	fileInfo, err := detector.Detect(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	w := os.Stdout
+	if outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	reporter, err := output.NewReporter(format, w)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	defer reporter.Close()
+
+	reporter.FileHeader(output.FileReport{
+		Name:     fileInfo.Name,
+		Path:     fileInfo.Path,
+		MimeType: fileInfo.MimeType,
+		Category: string(fileInfo.Category),
+		Size:     fileInfo.Size,
+	})
+
+	opts := runner.RunOpts{
+		Password:       password,
+		Verbose:        verbose,
+		OutputDir:      outputDir,
+		Skip:           skipTools,
+		Only:           onlyTools,
+		PerToolTimeout: time.Duration(timeout) * time.Second,
+		Concurrency:    jobs,
+		Quiet:          true,
+		MaxDepth:       maxDepth,
+	}
+
+	var scanResult *runner.ScanResult
+
+	if format == "json" {
+		// json streams as results come in; sarif buffers a single document
+		// and can only be built once the whole scan is done.
+		stream := make(chan *output.Result)
+		opts.Stream = stream
+		drained := make(chan struct{})
+		go func() {
+			defer close(drained)
+			for r := range stream {
+				reporter.ToolResult(r)
+			}
+		}()
+
+		scanResult = runner.RunAll(fileInfo, opts)
+		close(stream)
+		<-drained
+	} else {
+		scanResult = runner.RunAll(fileInfo, opts)
+		for _, r := range scanResult.Results {
+			reporter.ToolResult(r)
+		}
+	}
+
+	reporter.Summary(scanResult.Duration)
+}
+
+// stdoutIsTTY reports whether stdout looks like an interactive terminal,
+// used to pick a sensible default for --format when it isn't set explicitly.
+func stdoutIsTTY() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 var depsCmd = &cobra.Command{
 	Use:   "deps",
 	Short: "Check status of all steganography tools",
@@ -112,13 +262,18 @@ var depsCmd = &cobra.Command{
 	},
 }
 
+var installJobs int
+
 var installCmd = &cobra.Command{
 	Use:   "install",
 	Short: "Install all missing steganography tools",
 	Long:  "Automatically install all missing steganography tools using the system's package manager.",
 	Run: func(cmd *cobra.Command, args []string) {
 		output.PrintBanner()
-		deps.InstallMissing()
+		if err := deps.InstallMissingWithOptions(deps.InstallOptions{Concurrency: installJobs}); err != nil {
+			color.New(color.FgRed).Printf("  %v\n\n", err)
+			os.Exit(1)
+		}
 	},
 }
 
@@ -128,7 +283,16 @@ func init() {
 	scanCmd.Flags().StringSliceVar(&onlyTools, "only", nil, "Run only specific tools (comma-separated)")
 	scanCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "", "Output directory for extracted files (default: /tmp/steg-cli-output)")
 	scanCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show verbose output")
-	scanCmd.Flags().IntVarP(&timeout, "timeout", "t", 60, "Timeout per tool in seconds")
+	scanCmd.Flags().IntVarP(&timeout, "timeout", "t", 120, "Timeout per tool in seconds")
+	scanCmd.Flags().IntVarP(&jobs, "jobs", "j", runtime.NumCPU(), "Number of files to scan concurrently when <file|dir> is a directory, or of tools to run concurrently for a single file")
+	scanCmd.Flags().StringSliceVar(&includeGlobs, "include", nil, "Only scan files whose name matches this glob (comma-separated, directory mode only)")
+	scanCmd.Flags().StringSliceVar(&excludeGlobs, "exclude", nil, "Skip files whose name matches this glob (comma-separated, directory mode only)")
+	scanCmd.Flags().BoolVar(&skipUnknown, "skip-unknown", false, "Skip files whose type could not be detected (directory mode only)")
+	scanCmd.Flags().StringVar(&format, "format", "", "Output format: pretty, json, or sarif (default: pretty on a TTY, json otherwise); json and sarif scan a single file only, not a directory")
+	scanCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the report to this path instead of stdout (json/sarif only)")
+	scanCmd.Flags().IntVar(&maxDepth, "max-depth", 0, "How many carving hops to recurse into extracted artifacts (0 = use the built-in default)")
+
+	installCmd.Flags().IntVarP(&installJobs, "jobs", "j", runtime.NumCPU(), "Number of tools to install concurrently")
 
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(depsCmd)
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/canyouhack/steg-cli/pkg/toolspec"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var toolCmd = &cobra.Command{
+	Use:   "tool",
+	Short: "Inspect or extend the tool registry",
+	Long:  "List the tools steg-cli knows about, or register a new one, without recompiling.",
+}
+
+var toolListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all configured tools",
+	Long:  "Print every tool steg-cli will consider running, merging the built-in defaults with ~/.config/steg-cli/tools.yaml.",
+	Run: func(cmd *cobra.Command, args []string) {
+		specs, err := toolspec.Load()
+		if err != nil {
+			color.New(color.FgRed, color.Bold).Printf("  ❌ Error: %v\n\n", err)
+			os.Exit(1)
+		}
+
+		green := color.New(color.FgGreen, color.Bold)
+		gray := color.New(color.FgHiBlack)
+		yellow := color.New(color.FgYellow)
+		cyan := color.New(color.FgCyan)
+
+		fmt.Println()
+		for _, spec := range specs {
+			if spec.Disabled {
+				gray.Printf("  ⊘ %-20s", spec.Name)
+			} else {
+				green.Printf("  ✓ %-20s", spec.Name)
+			}
+			cyan.Printf("%-14s", spec.Category)
+			gray.Printf("binary=%s", spec.Binary)
+			if len(spec.Extensions) > 0 {
+				gray.Printf(" extensions=%s", strings.Join(spec.Extensions, ","))
+			}
+			if len(spec.MimeGlobs) > 0 {
+				gray.Printf(" mime=%s", strings.Join(spec.MimeGlobs, ","))
+			}
+			fmt.Println()
+			if spec.Disabled {
+				yellow.Printf("      disabled by %s\n", toolspec.ConfigPath())
+			}
+		}
+		fmt.Println()
+		gray.Printf("  %d tools (edit %s to add, override, or disable one)\n\n", len(specs), toolspec.ConfigPath())
+	},
+}
+
+var (
+	toolAddBinary      string
+	toolAddCategory    string
+	toolAddExtensions  []string
+	toolAddMimeGlobs   []string
+	toolAddCommand     []string
+	toolAddTimeout     int
+	toolAddPostExec    string
+	toolAddNeedRockyou bool
+)
+
+var toolAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Register a new tool (or override a built-in one)",
+	Long: `Add a tool definition to ~/.config/steg-cli/tools.yaml. The command is a
+list of text/template arguments; {{.File}}, {{.Password}}, {{.OutputDir}},
+and {{.Rockyou}} are available as placeholders, e.g.:
+
+  steg tool add mytool --binary mytool --category image \
+    --extensions .png,.bmp --command 'mytool,{{.File}},-o,{{.OutputDir}}/mytool.txt'
+
+Adding a tool whose name matches a built-in replaces it outright.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		if toolAddBinary == "" || len(toolAddCommand) == 0 {
+			color.New(color.FgRed, color.Bold).Printf("  ❌ Error: --binary and --command are required\n\n")
+			os.Exit(1)
+		}
+
+		spec := toolspec.Spec{
+			Name:            name,
+			Binary:          toolAddBinary,
+			Category:        toolAddCategory,
+			Extensions:      toolAddExtensions,
+			MimeGlobs:       toolAddMimeGlobs,
+			Command:         toolAddCommand,
+			TimeoutSeconds:  toolAddTimeout,
+			PostExecGlob:    toolAddPostExec,
+			RequiresRockyou: toolAddNeedRockyou,
+		}
+
+		if err := toolspec.Append(spec); err != nil {
+			color.New(color.FgRed, color.Bold).Printf("  ❌ Error: %v\n\n", err)
+			os.Exit(1)
+		}
+
+		color.New(color.FgGreen, color.Bold).Printf("  ✓ Added %q to %s\n\n", name, toolspec.ConfigPath())
+	},
+}
+
+func init() {
+	toolAddCmd.Flags().StringVar(&toolAddBinary, "binary", "", "Binary this tool execs (required)")
+	toolAddCmd.Flags().StringVar(&toolAddCategory, "category", "general", "Category: general, image, audio, or text")
+	toolAddCmd.Flags().StringSliceVar(&toolAddExtensions, "extensions", nil, "File extensions this tool applies to, comma-separated (e.g. .png,.bmp); omit for all files")
+	toolAddCmd.Flags().StringSliceVar(&toolAddMimeGlobs, "mime-globs", nil, "MIME type glob patterns this tool applies to, comma-separated (e.g. image/*)")
+	toolAddCmd.Flags().StringSliceVar(&toolAddCommand, "command", nil, "Command argv, comma-separated, templated with {{.File}}/{{.Password}}/{{.OutputDir}}/{{.Rockyou}} (required)")
+	toolAddCmd.Flags().IntVar(&toolAddTimeout, "timeout", 0, "Per-tool timeout override in seconds (0 = use the global --timeout)")
+	toolAddCmd.Flags().StringVar(&toolAddPostExec, "post-exec-path", "", "Path (relative to OutputDir) this tool writes its output to, for recursive carving")
+	toolAddCmd.Flags().BoolVar(&toolAddNeedRockyou, "requires-rockyou", false, "Resolve {{.Rockyou}} to the downloaded rockyou.txt wordlist before running")
+
+	toolCmd.AddCommand(toolListCmd)
+	toolCmd.AddCommand(toolAddCmd)
+	rootCmd.AddCommand(toolCmd)
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/canyouhack/steg-cli/pkg/detector"
+	"github.com/canyouhack/steg-cli/pkg/output"
+	"github.com/canyouhack/steg-cli/pkg/runner"
+	"github.com/fatih/color"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchRecursive  bool
+	watchDebounceMs int
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <dir>",
+	Short: "Watch a directory and scan new files as they appear",
+	Long: `Watch a directory for new or modified files and automatically run the
+full scan pipeline against each one as soon as it settles. Useful for
+pointing steg-cli at a CTF dropbox folder during a competition.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := args[0]
+
+		output.PrintBanner()
+
+		if err := runWatch(dir); err != nil {
+			color.New(color.FgRed, color.Bold).Printf("\n  ❌ Error: %v\n\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringVarP(&password, "password", "p", "", "Password for steghide/openstego extraction")
+	watchCmd.Flags().StringSliceVar(&skipTools, "skip", nil, "Skip specific tools (comma-separated)")
+	watchCmd.Flags().StringSliceVar(&onlyTools, "only", nil, "Run only specific tools (comma-separated)")
+	watchCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "", "Output directory for extracted files (default: /tmp/steg-cli-output)")
+	watchCmd.Flags().IntVarP(&timeout, "timeout", "t", 120, "Timeout per tool in seconds")
+	watchCmd.Flags().BoolVarP(&watchRecursive, "recursive", "r", false, "Also watch existing subdirectories and ones created at runtime")
+	watchCmd.Flags().IntVar(&watchDebounceMs, "debounce-ms", 500, "Quiet period after the last write before a file is scanned")
+	watchCmd.Flags().IntVar(&maxDepth, "max-depth", 0, "How many carving hops to recurse into extracted artifacts (0 = use the built-in default)")
+
+	rootCmd.AddCommand(watchCmd)
+}
+
+// runWatch watches dir (and, if --recursive was passed, its existing
+// subdirectories and any created under it at runtime) and runs the scan
+// pipeline against every
+// file once it has gone quiet for the configured debounce period.
+func runWatch(dir string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("cannot start watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("cannot watch %s: %w", dir, err)
+	}
+
+	if watchRecursive {
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || path == dir {
+				return nil
+			}
+			if info.IsDir() {
+				watcher.Add(path)
+			}
+			return nil
+		})
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	cyan.Printf("  👁  Watching %s for new files (Ctrl+C to stop)...\n\n", dir)
+
+	debounce := time.Duration(watchDebounceMs) * time.Millisecond
+
+	var mu sync.Mutex
+	pending := make(map[string]*time.Timer)
+
+	schedule := func(path string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if t, ok := pending[path]; ok {
+			t.Reset(debounce)
+			return
+		}
+		pending[path] = time.AfterFunc(debounce, func() {
+			mu.Lock()
+			delete(pending, path)
+			mu.Unlock()
+			scanWatchedFile(path)
+		})
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if watchRecursive {
+						watcher.Add(event.Name)
+					}
+					continue
+				}
+			}
+
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				schedule(event.Name)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			color.New(color.FgRed).Printf("  ⚠  watch error: %v\n", err)
+		}
+	}
+}
+
+// scanWatchedFile runs the standard scan pipeline against a file discovered
+// by the watcher and prints its results inline.
+func scanWatchedFile(path string) {
+	fileInfo, err := detector.Detect(path)
+	if err != nil {
+		// File may have already been removed, or still be mid-write past
+		// the debounce window; skip it rather than erroring the watch loop.
+		return
+	}
+
+	output.PrintFileInfo(fileInfo.Name, fileInfo.Path, fileInfo.MimeType, fileInfo.Size, string(fileInfo.Category))
+
+	opts := runner.RunOpts{
+		Password:       password,
+		Verbose:        verbose,
+		OutputDir:      outputDir,
+		Skip:           skipTools,
+		Only:           onlyTools,
+		PerToolTimeout: time.Duration(timeout) * time.Second,
+		MaxDepth:       maxDepth,
+	}
+
+	scanResult := runner.RunAll(fileInfo, opts)
+	runner.PrintResults(scanResult)
+}
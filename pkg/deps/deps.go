@@ -1,16 +1,26 @@
 package deps
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
+	"golang.org/x/sync/errgroup"
 )
 
 // Distro represents the Linux distribution
@@ -21,6 +31,8 @@ const (
 	DistroArch    Distro = "arch"    // Arch, Manjaro, EndeavourOS
 	DistroFedora  Distro = "fedora"  // Fedora, RHEL, CentOS, Rocky
 	DistroSuse    Distro = "suse"    // openSUSE
+	DistroAlpine  Distro = "alpine"  // Alpine Linux (apk)
+	DistroMacOS   Distro = "macos"   // macOS (Homebrew)
 	DistroUnknown Distro = "unknown"
 )
 
@@ -35,11 +47,30 @@ type ToolDep struct {
 	AurPkg      string   // AUR package name (for Arch when not in official repos)
 	DnfPkg      string
 	ZypperPkg   string
+	ApkPkg      string   // Alpine package name
+	BrewPkg     string   // Homebrew formula name (macOS)
 	PipPkg      string
 	GemPkg      string
 	GoPath      string
 	ManualURL   string
 	Description string
+	Recipe      *BuildRecipe // source build fallback, used when no distro package exists
+
+	VersionCmd   []string // e.g. []string{"binwalk", "--version"}; first element is usually Binary
+	VersionRegex string   // regex with one capture group pulling the version out of VersionCmd's output
+	MinVersion   string   // minimum acceptable dotted version; "" means any version is fine
+}
+
+// BuildRecipe describes how to build a tool from its upstream source when
+// the current distro has no package for it (no AUR-helper equivalent on
+// Fedora/openSUSE/etc). It mirrors what LURE does for Arch's AUR, but for
+// any distro that installSystem already knows how to fetch build deps for.
+type BuildRecipe struct {
+	SourceURL     string              // tarball to download and extract
+	SourceSHA256  string              // expected SHA-256 of the tarball at SourceURL; see installRecipe
+	BuildDeps     map[Distro][]string // build-time package names, per distro
+	BuildCommands []string            // shell commands run in the extracted source root, in order
+	BinaryName    string              // built binary, relative to the source root
 }
 
 // AllTools returns the complete list of tool dependencies
@@ -59,6 +90,7 @@ func AllTools() []ToolDep {
 			Name: "xxd", Binary: "xxd",
 			InstallType: "system",
 			AptPkg: "xxd", PacmanPkg: "xxd", DnfPkg: "vim-common", ZypperPkg: "vim-data-common",
+			ApkPkg: "xxd", BrewPkg: "",
 			Description: "Hex dump utility",
 		},
 		{
@@ -66,24 +98,34 @@ func AllTools() []ToolDep {
 			InstallType: "system",
 			AptPkg: "libimage-exiftool-perl", PacmanPkg: "perl-image-exiftool",
 			DnfPkg: "perl-Image-ExifTool", ZypperPkg: "exiftool",
-			Description: "Metadata extraction",
+			ApkPkg: "exiftool", BrewPkg: "exiftool",
+			Description:  "Metadata extraction",
+			VersionCmd:   []string{"exiftool", "-ver"},
+			VersionRegex: `([\d.]+)`,
 		},
 		{
 			Name: "binwalk", Binary: "binwalk",
 			InstallType: "system",
 			AptPkg: "binwalk", PacmanPkg: "binwalk", DnfPkg: "binwalk", ZypperPkg: "binwalk",
-			Description: "Embedded file detection",
+			ApkPkg: "binwalk", BrewPkg: "binwalk",
+			Description:  "Embedded file detection",
+			VersionCmd:   []string{"binwalk", "--version"},
+			VersionRegex: `Binwalk v([\d.]+)`,
+			MinVersion:   "3.0.0",
 		},
 		{
 			Name: "foremost", Binary: "foremost",
 			InstallType: "system",
 			AptPkg: "foremost", PacmanPkg: "foremost", DnfPkg: "foremost", ZypperPkg: "foremost",
+			ApkPkg: "", BrewPkg: "",
+			ManualURL: "https://github.com/korczis/foremost",
 			Description: "File carving tool",
 		},
 		{
 			Name: "steghide", Binary: "steghide",
 			InstallType: "system",
 			AptPkg: "steghide", PacmanPkg: "steghide", DnfPkg: "steghide", ZypperPkg: "steghide",
+			ApkPkg: "steghide", BrewPkg: "steghide",
 			Description: "Steganography hide/extract (JPG/BMP/WAV/AU)",
 		},
 		{
@@ -96,6 +138,7 @@ func AllTools() []ToolDep {
 			Name: "pngcheck", Binary: "pngcheck",
 			InstallType: "system",
 			AptPkg: "pngcheck", PacmanPkg: "pngcheck", DnfPkg: "pngcheck", ZypperPkg: "pngcheck",
+			ApkPkg: "pngcheck", BrewPkg: "pngcheck",
 			Description: "PNG integrity check",
 		},
 		{
@@ -108,17 +151,57 @@ func AllTools() []ToolDep {
 			Name: "stegseek", Binary: "stegseek",
 			InstallType: "system",
 			AptPkg: "stegseek", PacmanPkg: "", AurPkg: "stegseek",
-			DnfPkg: "", ZypperPkg: "",
+			DnfPkg: "", ZypperPkg: "", ApkPkg: "", BrewPkg: "stegseek",
 			ManualURL: "https://github.com/RickdeJager/stegseek/releases",
-			Description: "Fast steghide brute-force cracker",
+			Description:  "Fast steghide brute-force cracker",
+			VersionCmd:   []string{"stegseek", "--version"},
+			VersionRegex: `stegseek ([\d.]+)`,
+			Recipe: &BuildRecipe{
+				SourceURL: "https://github.com/RickdeJager/stegseek/archive/refs/tags/v0.6.tar.gz",
+				// SourceSHA256 could not be derived from the live asset in the
+				// environment this was authored in (no outbound network
+				// access). This placeholder is intentionally wrong so
+				// installRecipe fails closed — refusing to build from an
+				// unverified tarball — instead of silently skipping
+				// verification. Whoever has network access should download
+				// SourceURL, run `sha256sum` on it, and replace this value
+				// (see the SourceSHA256 verification in installRecipe for the
+				// STEG_CLI_SKIP_SOURCE_CHECKSUM / STEG_CLI_SOURCE_SHA256
+				// escape hatches in the meantime).
+				SourceSHA256: "0000000000000000000000000000000000000000000000000000000000000000",
+				BuildDeps: map[Distro][]string{
+					DistroFedora: {"cmake", "gcc-c++", "libjpeg-turbo-devel", "zlib-devel"},
+					DistroSuse:   {"cmake", "gcc-c++", "libjpeg8-devel", "zlib-devel"},
+				},
+				BuildCommands: []string{
+					"cmake -B build -DCMAKE_BUILD_TYPE=Release .",
+					"cmake --build build -j",
+				},
+				BinaryName: "build/stegseek",
+			},
 		},
 		{
 			Name: "openstego", Binary: "openstego",
 			InstallType: "system",
 			AptPkg: "openstego", PacmanPkg: "", AurPkg: "openstego",
-			DnfPkg: "", ZypperPkg: "",
+			DnfPkg: "", ZypperPkg: "", ApkPkg: "", BrewPkg: "",
 			ManualURL: "https://github.com/syvaidya/OpenStego/releases",
 			Description: "OpenStego extraction (PNG)",
+			Recipe: &BuildRecipe{
+				SourceURL: "https://github.com/syvaidya/openstego/archive/refs/tags/0.8.6.tar.gz",
+				// See the identical note on the stegseek recipe above:
+				// unverified placeholder, fails closed by design until
+				// someone with network access re-derives the real hash.
+				SourceSHA256: "0000000000000000000000000000000000000000000000000000000000000000",
+				BuildDeps: map[Distro][]string{
+					DistroFedora: {"maven", "java-17-openjdk-devel"},
+					DistroSuse:   {"maven", "java-17-openjdk-devel"},
+				},
+				BuildCommands: []string{
+					"mvn -q -DskipTests package",
+				},
+				BinaryName: "target/openstego",
+			},
 		},
 		{
 			Name: "jsteg", Binary: "jsteg",
@@ -131,12 +214,14 @@ func AllTools() []ToolDep {
 			InstallType: "system",
 			AptPkg: "graphicsmagick", PacmanPkg: "graphicsmagick",
 			DnfPkg: "GraphicsMagick", ZypperPkg: "GraphicsMagick",
+			ApkPkg: "graphicsmagick", BrewPkg: "graphicsmagick",
 			Description: "Image identification and analysis",
 		},
 		{
 			Name: "sox", Binary: "sox",
 			InstallType: "system",
 			AptPkg: "sox", PacmanPkg: "sox", DnfPkg: "sox", ZypperPkg: "sox",
+			ApkPkg: "sox", BrewPkg: "sox",
 			Description: "Audio spectrogram generation",
 		},
 		{
@@ -145,17 +230,14 @@ func AllTools() []ToolDep {
 			PipPkg: "stego-lsb",
 			Description: "WAV LSB steganography",
 		},
-		{
-			Name: "stegsolve", Binary: "python3",
-			InstallType: "pip",
-			PipPkg: "Pillow",
-			Description: "Stegsolve-like bitplane extraction",
-		},
 	}
 }
 
-// DetectDistro detects the current Linux distribution
+// DetectDistro detects the current OS/distribution
 func DetectDistro() Distro {
+	if runtime.GOOS == "darwin" {
+		return DistroMacOS
+	}
 	if runtime.GOOS != "linux" {
 		return DistroUnknown
 	}
@@ -173,11 +255,17 @@ func DetectDistro() Distro {
 	if isCommandAvailable("zypper") {
 		return DistroSuse
 	}
+	if isCommandAvailable("apk") {
+		return DistroAlpine
+	}
 
 	// Fallback: read /etc/os-release
 	data, err := os.ReadFile("/etc/os-release")
 	if err == nil {
 		content := strings.ToLower(string(data))
+		if strings.Contains(content, "alpine") {
+			return DistroAlpine
+		}
 		if strings.Contains(content, "ubuntu") || strings.Contains(content, "debian") || strings.Contains(content, "kali") || strings.Contains(content, "mint") {
 			return DistroDebian
 		}
@@ -224,6 +312,171 @@ func CheckAll() map[string]bool {
 	return status
 }
 
+// ToolStatus is a tool's detailed availability, as returned by
+// CheckAllDetailed.
+type ToolStatus struct {
+	Installed bool
+	Version   string
+	MeetsMin  bool
+	Path      string
+}
+
+// cachedToolVersion is one tool's entry in the on-disk version cache. It is
+// keyed by the binary's mtime so a reinstalled/upgraded tool is re-probed
+// instead of returning a stale version forever.
+type cachedToolVersion struct {
+	Mtime   int64  `json:"mtime"`
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// versionStateFile is the on-disk shape of ~/.steg-cli/state.json.
+type versionStateFile struct {
+	Tools map[string]cachedToolVersion `json:"tools"`
+}
+
+func stateFilePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".steg-cli", "state.json")
+}
+
+func loadVersionState() versionStateFile {
+	state := versionStateFile{Tools: make(map[string]cachedToolVersion)}
+	data, err := os.ReadFile(stateFilePath())
+	if err != nil {
+		return state
+	}
+	json.Unmarshal(data, &state)
+	if state.Tools == nil {
+		state.Tools = make(map[string]cachedToolVersion)
+	}
+	return state
+}
+
+func saveVersionState(state versionStateFile) error {
+	path := stateFilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// CheckAllDetailed checks every tool's presence, path and version. Versions
+// are expensive to probe (they fork the tool), so results are cached in
+// ~/.steg-cli/state.json keyed by the resolved binary's mtime; a tool that
+// hasn't changed on disk since the last run is not re-probed.
+func CheckAllDetailed() map[string]ToolStatus {
+	state := loadVersionState()
+	changed := false
+
+	result := make(map[string]ToolStatus)
+
+	for _, tool := range AllTools() {
+		path, err := exec.LookPath(tool.Binary)
+		if err != nil {
+			for _, alt := range tool.AltBinaries {
+				if p, aerr := exec.LookPath(alt); aerr == nil {
+					path, err = p, nil
+					break
+				}
+			}
+		}
+		if err != nil {
+			result[tool.Name] = ToolStatus{Installed: false}
+			continue
+		}
+
+		var mtime int64
+		if info, statErr := os.Stat(path); statErr == nil {
+			mtime = info.ModTime().Unix()
+		}
+
+		version := ""
+		if cached, ok := state.Tools[tool.Name]; ok && cached.Path == path && cached.Mtime == mtime {
+			version = cached.Version
+		} else if len(tool.VersionCmd) > 0 {
+			version = detectVersion(tool)
+			state.Tools[tool.Name] = cachedToolVersion{Mtime: mtime, Path: path, Version: version}
+			changed = true
+		}
+
+		result[tool.Name] = ToolStatus{
+			Installed: true,
+			Path:      path,
+			Version:   version,
+			MeetsMin:  meetsMinVersion(version, tool.MinVersion),
+		}
+	}
+
+	if changed {
+		saveVersionState(state)
+	}
+
+	return result
+}
+
+// detectVersion runs tool.VersionCmd and pulls the version out of its
+// output with tool.VersionRegex (or returns the trimmed output verbatim if
+// no regex is set).
+func detectVersion(tool ToolDep) string {
+	if len(tool.VersionCmd) == 0 {
+		return ""
+	}
+	out, _ := exec.Command(tool.VersionCmd[0], tool.VersionCmd[1:]...).CombinedOutput()
+
+	if tool.VersionRegex == "" {
+		return strings.TrimSpace(string(out))
+	}
+	re, err := regexp.Compile(tool.VersionRegex)
+	if err != nil {
+		return ""
+	}
+	match := re.FindStringSubmatch(string(out))
+	if len(match) < 2 {
+		return ""
+	}
+	return match[1]
+}
+
+// meetsMinVersion reports whether version satisfies min. A missing version
+// or unset minimum is always treated as satisfied, since we'd rather not
+// flag a tool "outdated" on a version string we couldn't parse.
+func meetsMinVersion(version, min string) bool {
+	if min == "" || version == "" {
+		return true
+	}
+	return compareVersions(version, min) >= 0
+}
+
+// compareVersions compares two dotted version strings (e.g. "3.1.0")
+// component by component, returning -1, 0, or 1. Non-numeric components
+// compare as 0 so odd formats don't cause a panic.
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			an, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bn, _ = strconv.Atoi(bs[i])
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // PrintStatus prints the status of all tools with color
 func PrintStatus() {
 	green := color.New(color.FgGreen, color.Bold)
@@ -237,94 +490,234 @@ func PrintStatus() {
 	white.Printf("%s\n\n", distro)
 
 	tools := AllTools()
-	installed := 0
+	statuses := CheckAllDetailed()
+	installed, outdated := 0, 0
 
-	fmt.Printf("  %-20s %-12s %s\n", "TOOL", "STATUS", "DESCRIPTION")
-	fmt.Printf("  %s\n", strings.Repeat("─", 65))
+	fmt.Printf("  %-20s %-12s %-12s %s\n", "TOOL", "STATUS", "VERSION", "DESCRIPTION")
+	fmt.Printf("  %s\n", strings.Repeat("─", 80))
 
 	for _, tool := range tools {
-		available := isCommandAvailable(tool.Binary)
-		if !available {
-			for _, alt := range tool.AltBinaries {
-				if isCommandAvailable(alt) {
-					available = true
-					break
-				}
-			}
-		}
+		st := statuses[tool.Name]
 
-		if available {
+		fmt.Printf("  %-20s ", tool.Name)
+		switch {
+		case !st.Installed:
+			red.Printf("%-12s", "✗ missing")
+		case !st.MeetsMin:
+			outdated++
+			installed++
+			yellow.Printf("%-12s", "⚠ outdated")
+		default:
 			installed++
-			fmt.Printf("  %-20s ", tool.Name)
 			green.Printf("%-12s", "✓ ready")
-			fmt.Printf(" %s\n", tool.Description)
-		} else {
-			fmt.Printf("  %-20s ", tool.Name)
-			red.Printf("%-12s", "✗ missing")
-			fmt.Printf(" %s\n", tool.Description)
 		}
+
+		version := st.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Printf("%-12s %s\n", version, tool.Description)
 	}
 
-	fmt.Printf("\n  %s\n", strings.Repeat("─", 65))
-	if installed == len(tools) {
+	fmt.Printf("\n  %s\n", strings.Repeat("─", 80))
+	switch {
+	case installed == len(tools) && outdated == 0:
 		green.Printf("  ✓ All %d tools are installed and ready!\n\n", installed)
-	} else {
+	case installed == len(tools):
+		yellow.Printf("  ⚠ All %d tools installed, but %d below the recommended minimum version.\n\n", installed, outdated)
+	default:
 		yellow.Printf("  ⚠ %d/%d tools installed. Missing tools will be skipped during scan.\n", installed, len(tools))
 		yellow.Printf("  Run 'steg install' to install missing tools.\n\n")
 	}
 }
 
-// InstallMissing attempts to install all missing tools
+// InstallOptions configures InstallMissing's worker pool.
+type InstallOptions struct {
+	// Concurrency caps how many installers run at once. <= 0 picks a
+	// default equal to the number of tools in AllTools().
+	Concurrency int
+}
+
+// installStatus is a tool's position in the pending -> installing ->
+// done/failed pipeline, used to drive the live status table.
+type installStatus string
+
+const (
+	statusPending    installStatus = "pending"
+	statusInstalling installStatus = "installing"
+	statusDone       installStatus = "done"
+	statusFailed     installStatus = "failed"
+)
+
+// installRow is one line of the live status table.
+type installRow struct {
+	name   string
+	status installStatus
+	detail string
+}
+
+// installTable renders InstallMissing's progress as a table that redraws in
+// place, one row per tool, as each moves through the pipeline.
+type installTable struct {
+	mu   sync.Mutex
+	rows []*installRow
+}
+
+func newInstallTable(tools []ToolDep) *installTable {
+	t := &installTable{}
+	for _, tool := range tools {
+		t.rows = append(t.rows, &installRow{name: tool.Name, status: statusPending})
+	}
+	return t
+}
+
+// print renders the table for the first time; subsequent updates go through
+// set, which redraws every row in place.
+func (t *installTable) print() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, r := range t.rows {
+		fmt.Println(formatInstallRow(r))
+	}
+}
+
+// set updates a single row's status and redraws the whole table. Safe to
+// call from multiple goroutines.
+func (t *installTable) set(name string, status installStatus, detail string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, r := range t.rows {
+		if r.name == name {
+			r.status = status
+			r.detail = detail
+			break
+		}
+	}
+	fmt.Printf("\033[%dA", len(t.rows))
+	for _, r := range t.rows {
+		fmt.Printf("\033[2K%s\n", formatInstallRow(r))
+	}
+}
+
+func formatInstallRow(r *installRow) string {
+	var label string
+	statusColor := color.New(color.FgHiBlack)
+	switch r.status {
+	case statusPending:
+		label = "pending"
+	case statusInstalling:
+		statusColor, label = color.New(color.FgYellow), "installing…"
+	case statusDone:
+		statusColor, label = color.New(color.FgGreen), "✓ done"
+	case statusFailed:
+		statusColor, label = color.New(color.FgRed), "✗ failed"
+	}
+	row := fmt.Sprintf("  %-20s %s", r.name, statusColor.Sprintf("%-14s", label))
+	if r.detail != "" {
+		row += " " + r.detail
+	}
+	return row
+}
+
+// InstallMissing attempts to install all missing tools, using the default
+// concurrency.
 func InstallMissing() error {
+	return InstallMissingWithOptions(InstallOptions{})
+}
+
+// InstallMissingWithOptions installs all missing tools in parallel. System
+// package installs (apt/dnf/pacman/zypper/apk/brew, including the
+// installRecipe source-build fallback) are serialized behind a single lock,
+// since those package managers can't run more than one instance against
+// themselves at a time; pip, gem, and go install all run independently of
+// that lock and of each other.
+func InstallMissingWithOptions(opts InstallOptions) error {
 	distro := DetectDistro()
-	green := color.New(color.FgGreen)
-	red := color.New(color.FgRed)
 	cyan := color.New(color.FgCyan, color.Bold)
-	yellow := color.New(color.FgYellow)
 
 	cyan.Printf("\n  📦 Installing missing dependencies...\n")
 	cyan.Printf("  🖥  Detected distro: %s\n\n", distro)
 
 	tools := AllTools()
+	table := newInstallTable(tools)
+	table.print()
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = len(tools)
+	}
+
+	var eg errgroup.Group
+	eg.SetLimit(concurrency)
+	var systemMu sync.Mutex
+
+	var errsMu sync.Mutex
+	var errs []error
+
 	for _, tool := range tools {
+		tool := tool
+
 		if isCommandAvailable(tool.Binary) {
-			green.Printf("  ✓ %s already installed\n", tool.Name)
+			table.set(tool.Name, statusDone, "already installed")
 			continue
 		}
-
 		if tool.InstallType == "builtin" {
+			table.set(tool.Name, statusDone, "built in")
 			continue
 		}
 
-		fmt.Printf("  ⏳ Installing %s...", tool.Name)
+		eg.Go(func() error {
+			table.set(tool.Name, statusInstalling, "")
 
-		var err error
-		switch tool.InstallType {
-		case "system":
-			err = installSystem(tool, distro)
-		case "pip":
-			err = installPip(tool)
-		case "gem":
-			err = installGem(tool)
-		case "go":
-			err = installGo(tool)
-		}
+			var err error
+			switch tool.InstallType {
+			case "system":
+				systemMu.Lock()
+				err = installSystem(tool, distro)
+				systemMu.Unlock()
+			case "pip":
+				err = installPip(tool)
+			case "gem":
+				err = installGem(tool)
+			case "go":
+				err = installGo(tool)
+			case "recipe":
+				systemMu.Lock()
+				err = installRecipe(tool, distro)
+				systemMu.Unlock()
+			}
 
-		if err != nil {
-			red.Printf(" ✗ failed: %v\n", err)
-			if tool.ManualURL != "" {
-				yellow.Printf("    → Manual install: %s\n", tool.ManualURL)
+			if err != nil {
+				detail := err.Error()
+				if tool.ManualURL != "" {
+					detail += " (manual: " + tool.ManualURL + ")"
+				}
+				table.set(tool.Name, statusFailed, detail)
+				errsMu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", tool.Name, err))
+				errsMu.Unlock()
+				return nil
 			}
-		} else {
-			green.Printf(" ✓ done\n")
-		}
+
+			table.set(tool.Name, statusDone, "")
+			return nil
+		})
 	}
 
+	// Errors are aggregated below rather than propagated through the
+	// group, so every tool gets a chance to install even if another fails.
+	eg.Wait()
+
 	// Ensure rockyou.txt exists for brute-force tools
 	ensureRockyou()
 
-	green.Printf("\n  ✓ Installation complete!\n\n")
-	return nil
+	fmt.Println()
+	if len(errs) == 0 {
+		color.New(color.FgGreen).Printf("  ✓ Installation complete!\n\n")
+		return nil
+	}
+	color.New(color.FgYellow).Printf("  ⚠ Installation finished with %d failure(s).\n\n", len(errs))
+	return errors.Join(errs...)
 }
 
 func installSystem(tool ToolDep, distro Distro) error {
@@ -346,14 +739,30 @@ func installSystem(tool ToolDep, distro Distro) error {
 		}
 	case DistroFedora:
 		if tool.DnfPkg == "" {
+			if tool.Recipe != nil {
+				return installRecipe(tool, distro)
+			}
 			return fmt.Errorf("no dnf package available")
 		}
 		cmd = exec.Command("sudo", "dnf", "install", "-y", tool.DnfPkg)
 	case DistroSuse:
 		if tool.ZypperPkg == "" {
+			if tool.Recipe != nil {
+				return installRecipe(tool, distro)
+			}
 			return fmt.Errorf("no zypper package available")
 		}
 		cmd = exec.Command("sudo", "zypper", "install", "-y", tool.ZypperPkg)
+	case DistroAlpine:
+		if tool.ApkPkg == "" {
+			return fmt.Errorf("no apk package available")
+		}
+		cmd = exec.Command("sudo", "apk", "add", "--no-cache", tool.ApkPkg)
+	case DistroMacOS:
+		if tool.BrewPkg == "" {
+			return fmt.Errorf("no brew formula available")
+		}
+		cmd = exec.Command("brew", "install", "--formulae", tool.BrewPkg)
 	default:
 		return fmt.Errorf("unknown distro, cannot install automatically")
 	}
@@ -392,6 +801,268 @@ func installAUR(pkg string) error {
 	return fmt.Errorf("no AUR helper (yay/paru) found or install failed for %s", pkg)
 }
 
+// localBinDir returns ~/.steg-cli/bin, where installRecipe drops binaries it
+// builds from source.
+func localBinDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".steg-cli", "bin")
+}
+
+func init() {
+	if info, err := os.Stat(localBinDir()); err == nil && info.IsDir() {
+		prependToPATH(localBinDir())
+	}
+}
+
+// prependToPATH adds dir to the front of this process's PATH, so later
+// exec.LookPath calls (IsToolAvailable, InstallMissingWithOptions's
+// already-installed check, ...)
+// find binaries installRecipe has built.
+func prependToPATH(dir string) {
+	path := os.Getenv("PATH")
+	if strings.Contains(path, dir) {
+		return
+	}
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+}
+
+// installRecipe builds a tool from source when its distro has no package
+// for it, the same "build from upstream" fallback LURE gives AUR ports.
+func installRecipe(tool ToolDep, distro Distro) error {
+	recipe := tool.Recipe
+	if recipe == nil {
+		return fmt.Errorf("no build recipe available for %s", tool.Name)
+	}
+
+	for _, pkg := range recipe.BuildDeps[distro] {
+		if err := installDistroPackage(pkg, distro); err != nil {
+			return fmt.Errorf("build dependency %s: %w", pkg, err)
+		}
+	}
+
+	buildDir := filepath.Join(filepath.Dir(localBinDir()), "build", tool.Name)
+	if err := os.RemoveAll(buildDir); err != nil {
+		return fmt.Errorf("clear build dir: %w", err)
+	}
+	if err := os.MkdirAll(buildDir, 0755); err != nil {
+		return fmt.Errorf("create build dir: %w", err)
+	}
+
+	archivePath := filepath.Join(buildDir, filepath.Base(recipe.SourceURL))
+	if err := downloadFile(recipe.SourceURL, archivePath); err != nil {
+		return fmt.Errorf("download source: %w", err)
+	}
+	if err := verifySourceChecksum(archivePath, recipe.SourceSHA256); err != nil {
+		return fmt.Errorf("verify source: %w", err)
+	}
+	if err := extractTarGz(archivePath, buildDir); err != nil {
+		return fmt.Errorf("extract source: %w", err)
+	}
+
+	srcRoot, err := singleSubdir(buildDir)
+	if err != nil {
+		return fmt.Errorf("locate extracted source: %w", err)
+	}
+
+	for _, step := range recipe.BuildCommands {
+		cmd := exec.Command("bash", "-c", step)
+		cmd.Dir = srcRoot
+		cmd.Stdout = io.Discard
+		cmd.Stderr = io.Discard
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("build step %q: %w", step, err)
+		}
+	}
+
+	binDir := localBinDir()
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return fmt.Errorf("create bin dir: %w", err)
+	}
+	if err := copyExecutable(filepath.Join(srcRoot, recipe.BinaryName), filepath.Join(binDir, tool.Binary)); err != nil {
+		return fmt.Errorf("install built binary: %w", err)
+	}
+
+	prependToPATH(binDir)
+	return nil
+}
+
+// installDistroPackage installs a single build-dependency package by name,
+// using the same package manager installSystem would for a ToolDep, but
+// without requiring one to already exist in AllTools().
+func installDistroPackage(pkg string, distro Distro) error {
+	var cmd *exec.Cmd
+	switch distro {
+	case DistroDebian:
+		cmd = exec.Command("sudo", "apt-get", "install", "-y", pkg)
+	case DistroArch:
+		cmd = exec.Command("sudo", "pacman", "-S", "--noconfirm", pkg)
+	case DistroFedora:
+		cmd = exec.Command("sudo", "dnf", "install", "-y", pkg)
+	case DistroSuse:
+		cmd = exec.Command("sudo", "zypper", "install", "-y", pkg)
+	case DistroAlpine:
+		cmd = exec.Command("sudo", "apk", "add", "--no-cache", pkg)
+	case DistroMacOS:
+		cmd = exec.Command("brew", "install", "--formulae", pkg)
+	default:
+		return fmt.Errorf("unknown distro, cannot install build dependency %s", pkg)
+	}
+	cmd.Stdout = io.Discard
+	cmd.Stderr = io.Discard
+	return cmd.Run()
+}
+
+// downloadFile fetches url and writes it to dest.
+func downloadFile(url, dest string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+// ErrSourceChecksumMismatch is returned by verifySourceChecksum when a
+// downloaded build recipe's tarball doesn't match its recipe's SourceSHA256.
+var ErrSourceChecksumMismatch = errors.New("source tarball checksum mismatch")
+
+// verifySourceChecksum checks archivePath's SHA-256 against want before
+// installRecipe extracts and builds it, the same protection b3ab4bb added
+// for rockyou.txt (see rockyouSHA256/downloadRockyou): installRecipe runs
+// BuildCommands via a shell, so an unverified tarball is arbitrary code
+// execution on anyone compromising SourceURL or the path to it.
+//
+// STEG_CLI_SKIP_SOURCE_CHECKSUM=1 accepts the download without checking it
+// at all; STEG_CLI_SOURCE_SHA256 overrides the expected hash for a
+// legitimate release whose tarball changed out from under a stale recipe.
+func verifySourceChecksum(archivePath, want string) error {
+	if os.Getenv("STEG_CLI_SKIP_SOURCE_CHECKSUM") == "1" {
+		return nil
+	}
+	if override := os.Getenv("STEG_CLI_SOURCE_SHA256"); override != "" {
+		want = override
+	}
+
+	sum, err := sha256File(archivePath)
+	if err != nil {
+		return err
+	}
+	if sum != want {
+		return ErrSourceChecksumMismatch
+	}
+	return nil
+}
+
+// extractTarGz extracts a .tar.gz archive into destDir.
+func extractTarGz(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := safeJoin(destDir, hdr.Name)
+		if err != nil {
+			return fmt.Errorf("extracting %s: %w", archivePath, err)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			out.Close()
+		}
+	}
+}
+
+// safeJoin resolves name against destDir the way extractTarGz needs to (a
+// tar entry may legitimately nest subdirectories, so it can't just take
+// filepath.Base like polyglot.Extract does), while rejecting any entry whose
+// name (e.g. "../../etc/passwd" or an absolute path) would resolve outside
+// destDir — a tar archive is untrusted input, and nothing stops a crafted or
+// MITM'd tarball from trying a tar-slip path-traversal write.
+func safeJoin(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, name)
+	destDir = filepath.Clean(destDir)
+	if target != destDir && !strings.HasPrefix(target, destDir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("tar entry %q escapes destination directory", name)
+	}
+	return target, nil
+}
+
+// singleSubdir returns the single subdirectory of dir, which is the
+// convention source tarballs extract to (e.g. stegseek-0.6/).
+func singleSubdir(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			return filepath.Join(dir, e.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("no extracted source directory found in %s", dir)
+}
+
+// copyExecutable copies src to dst and makes dst executable.
+func copyExecutable(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
 func installPip(tool ToolDep) error {
 	// Try pip3 first, then pip
 	pip := "pip3"
@@ -466,12 +1137,18 @@ func RockyouPath() string {
 	return localPath // Return the local path even if not downloaded yet
 }
 
-// EnsureRockyouExists checks if rockyou.txt exists and downloads if needed
-func EnsureRockyouExists() string {
+// EnsureRockyouExists checks if rockyou.txt exists and downloads it if
+// needed, returning its path. The second return value is nil on success and
+// non-nil on failure — callers that only care about the path for a simple
+// "did this work" check can ignore it, but a caller that wants to tell
+// ErrChecksumMismatch apart from, say, a network error (to decide whether
+// retrying or prompting for a manual wordlist makes sense) can check it with
+// errors.Is.
+func EnsureRockyouExists() (string, error) {
 	return ensureRockyou()
 }
 
-func ensureRockyou() string {
+func ensureRockyou() (string, error) {
 	green := color.New(color.FgGreen)
 	yellow := color.New(color.FgYellow)
 
@@ -483,7 +1160,7 @@ func ensureRockyou() string {
 	for _, p := range systemPaths {
 		if _, err := os.Stat(p); err == nil {
 			green.Printf("  ✓ rockyou.txt found at %s\n", p)
-			return p
+			return p, nil
 		}
 	}
 
@@ -498,7 +1175,7 @@ func ensureRockyou() string {
 			if err := cmd.Run(); err == nil {
 				txtPath := strings.TrimSuffix(p, ".gz")
 				green.Printf("  ✓ Extracted to %s\n", txtPath)
-				return txtPath
+				return txtPath, nil
 			}
 		}
 	}
@@ -510,38 +1187,173 @@ func ensureRockyou() string {
 
 	if _, err := os.Stat(localPath); err == nil {
 		green.Printf("  ✓ rockyou.txt found at %s\n", localPath)
-		return localPath
+		return localPath, nil
 	}
 
-	yellow.Printf("  ⏳ Downloading rockyou.txt (14MB)...\n")
 	if err := os.MkdirAll(localDir, 0755); err != nil {
 		yellow.Printf("  ⚠ Cannot create wordlist directory: %v\n", err)
-		return ""
+		return "", err
 	}
 
-	// Download from GitHub
-	url := "https://github.com/brannondorsey/naive-hashcat/releases/download/data/rockyou.txt"
-	resp, err := http.Get(url)
-	if err != nil {
+	yellow.Printf("  ⏳ Downloading rockyou.txt (14MB)...\n")
+	var lastErr error
+	for attempt := 1; attempt <= 2; attempt++ {
+		err := downloadRockyou(localPath)
+		if err == nil {
+			fmt.Println()
+			green.Printf("  ✓ Downloaded and verified rockyou.txt at %s\n", localPath)
+			return localPath, nil
+		}
+		lastErr = err
+		if errors.Is(err, ErrChecksumMismatch) && attempt == 1 {
+			fmt.Println()
+			yellow.Printf("  ⚠ Checksum mismatch, retrying download...\n")
+			continue
+		}
+		fmt.Println()
 		yellow.Printf("  ⚠ Cannot download rockyou.txt: %v\n", err)
-		return ""
+		return "", lastErr
+	}
+
+	return "", lastErr
+}
+
+// rockyouURL is the upstream mirror of rockyou.txt used by ensureRockyou.
+const rockyouURL = "https://github.com/brannondorsey/naive-hashcat/releases/download/data/rockyou.txt"
+
+// rockyouSHA256 is the expected SHA-256 of the rockyou.txt asset published
+// at rockyouURL. To (re-)derive it yourself:
+//
+//	curl -fsSL 'https://github.com/brannondorsey/naive-hashcat/releases/download/data/rockyou.txt' | sha256sum
+//
+// The value this constant previously held was one hex digit short (63
+// characters, not 64) and so could never have matched a real download —
+// TestRockyouSHA256Format now guards against that specific mistake
+// recurring. There was no pinned test fixture or documented derivation
+// anywhere in the tree to recover the intended value from, and this
+// environment has no outbound network access to run the command above, so
+// this is a placeholder: intentionally well-formed but wrong, so downloads
+// fail closed (loudly, via ErrChecksumMismatch) instead of silently
+// accepting anything. Whoever has network access should run the command
+// above and replace this with the real hash.
+//
+// If a legitimate download ever fails verification against this value — a
+// stale constant, not a corrupted transfer — set STEG_CLI_ROCKYOU_SHA256 to
+// override the expected hash, or STEG_CLI_SKIP_ROCKYOU_CHECKSUM=1 to accept
+// whatever was downloaded without checking it at all.
+const rockyouSHA256 = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// ErrChecksumMismatch is returned by downloadRockyou when the downloaded
+// file's SHA-256 does not match rockyouSHA256.
+var ErrChecksumMismatch = errors.New("rockyou.txt checksum mismatch")
+
+// downloadRockyou downloads rockyou.txt to finalPath, resuming a partial
+// download via an HTTP Range request if finalPath+".part" already exists,
+// verifying its SHA-256 once complete, and atomically renaming it into
+// place only after verification succeeds.
+func downloadRockyou(finalPath string) error {
+	partPath := finalPath + ".part"
+
+	var resumeFrom int64
+	if info, err := os.Stat(partPath); err == nil {
+		resumeFrom = info.Size()
+	}
+
+	req, err := http.NewRequest("GET", rockyouURL, nil)
+	if err != nil {
+		return err
+	}
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
 	}
 	defer resp.Body.Close()
 
-	out, err := os.Create(localPath)
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// Server ignored our Range header (or there was nothing to
+		// resume); start the file over.
+		resumeFrom = 0
+		flags |= os.O_TRUNC
+	default:
+		return fmt.Errorf("unexpected status %s downloading rockyou.txt", resp.Status)
+	}
+
+	out, err := os.OpenFile(partPath, flags, 0644)
 	if err != nil {
-		yellow.Printf("  ⚠ Cannot create file: %v\n", err)
-		return ""
+		return err
 	}
-	defer out.Close()
 
-	_, err = io.Copy(out, resp.Body)
+	total := resumeFrom + resp.ContentLength
+	progress := &downloadProgress{done: resumeFrom, total: total}
+
+	_, err = io.Copy(out, io.TeeReader(resp.Body, progress))
+	closeErr := out.Close()
 	if err != nil {
-		yellow.Printf("  ⚠ Download failed: %v\n", err)
-		os.Remove(localPath)
-		return ""
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	if os.Getenv("STEG_CLI_SKIP_ROCKYOU_CHECKSUM") == "1" {
+		return os.Rename(partPath, finalPath)
+	}
+
+	want := rockyouSHA256
+	if override := os.Getenv("STEG_CLI_ROCKYOU_SHA256"); override != "" {
+		want = override
+	}
+
+	sum, err := sha256File(partPath)
+	if err != nil {
+		return err
+	}
+	if sum != want {
+		os.Remove(partPath)
+		return ErrChecksumMismatch
 	}
 
-	green.Printf("  ✓ Downloaded rockyou.txt to %s\n", localPath)
-	return localPath
+	return os.Rename(partPath, finalPath)
+}
+
+// downloadProgress is an io.Writer that prints a percentage progress line
+// as bytes are written through it.
+type downloadProgress struct {
+	done, total int64
+	lastPct     int
+}
+
+func (p *downloadProgress) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+	if p.total > 0 {
+		pct := int(p.done * 100 / p.total)
+		if pct != p.lastPct {
+			p.lastPct = pct
+			fmt.Printf("\r  ⏳ Downloading rockyou.txt... %d%%", pct)
+		}
+	}
+	return len(b), nil
+}
+
+// sha256File returns the hex-encoded SHA-256 of a file's contents.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
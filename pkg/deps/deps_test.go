@@ -0,0 +1,17 @@
+package deps
+
+import (
+	"regexp"
+	"testing"
+)
+
+var sha256HexRe = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// TestRockyouSHA256Format is a sanity check on rockyouSHA256's shape, not
+// proof it matches the real rockyou.txt asset — see the doc comment above
+// the constant for how to (re-)derive and confirm that.
+func TestRockyouSHA256Format(t *testing.T) {
+	if !sha256HexRe.MatchString(rockyouSHA256) {
+		t.Fatalf("rockyouSHA256 = %q, want 64 lowercase hex characters", rockyouSHA256)
+	}
+}
@@ -23,9 +23,160 @@ const (
 	CategoryFLAC    FileCategory = "flac"
 	CategoryOGG     FileCategory = "ogg"
 	CategoryAU      FileCategory = "au"
+	CategoryM4A     FileCategory = "m4a"
+	CategoryWMA     FileCategory = "wma"
+	CategoryAAC     FileCategory = "aac"
+	CategoryDSF     FileCategory = "dsf"
+	CategoryDFF     FileCategory = "dff"
+	CategoryHEIC    FileCategory = "heic"
+	CategoryAVIF    FileCategory = "avif"
 	CategoryUnknown FileCategory = "unknown"
 )
 
+// Kind tags used to classify a FileCategory for IsImage/IsAudio.
+const (
+	KindImage = "image"
+	KindAudio = "audio"
+)
+
+// Sniffer inspects the first bytes of a file (up to 512) and reports
+// whether they match its format.
+type Sniffer func(header []byte) bool
+
+// registration pairs a FileCategory with the sniffer that detects it.
+type registration struct {
+	category FileCategory
+	sniffer  Sniffer
+}
+
+var (
+	snifferRegistry []registration
+	extRegistry     = map[string]FileCategory{}
+	kindRegistry    = map[FileCategory]string{}
+)
+
+// Register adds a new file format to the detector. exts are the
+// lowercase, dot-prefixed extensions (e.g. ".png") used as a fallback
+// when no sniffer matches, and kind classifies the category for
+// IsImage/IsAudio (use KindImage, KindAudio, or "" for neither).
+func Register(category FileCategory, kind string, exts []string, sniffer Sniffer) {
+	if sniffer != nil {
+		snifferRegistry = append(snifferRegistry, registration{category: category, sniffer: sniffer})
+	}
+	if kind != "" {
+		kindRegistry[category] = kind
+	}
+	for _, ext := range exts {
+		extRegistry[ext] = category
+	}
+}
+
+func init() {
+	Register(CategoryPNG, KindImage, []string{".png"}, func(h []byte) bool {
+		return len(h) >= 4 && h[0] == 0x89 && h[1] == 0x50 && h[2] == 0x4E && h[3] == 0x47
+	})
+	Register(CategoryJPG, KindImage, []string{".jpg", ".jpeg", ".jfif", ".jpe"}, func(h []byte) bool {
+		return len(h) >= 3 && h[0] == 0xFF && h[1] == 0xD8 && h[2] == 0xFF
+	})
+	Register(CategoryBMP, KindImage, []string{".bmp"}, func(h []byte) bool {
+		return len(h) >= 2 && h[0] == 0x42 && h[1] == 0x4D
+	})
+	Register(CategoryGIF, KindImage, []string{".gif"}, func(h []byte) bool {
+		return len(h) >= 4 && h[0] == 0x47 && h[1] == 0x49 && h[2] == 0x46 && h[3] == 0x38
+	})
+	Register(CategoryTIFF, KindImage, []string{".tiff", ".tif"}, func(h []byte) bool {
+		if len(h) < 4 {
+			return false
+		}
+		return (h[0] == 0x49 && h[1] == 0x49 && h[2] == 0x2A && h[3] == 0x00) ||
+			(h[0] == 0x4D && h[1] == 0x4D && h[2] == 0x00 && h[3] == 0x2A)
+	})
+	Register(CategoryWAV, KindAudio, []string{".wav"}, func(h []byte) bool {
+		return isRIFF(h, "WAVE")
+	})
+	Register(CategoryWEBP, KindImage, []string{".webp"}, func(h []byte) bool {
+		return isRIFF(h, "WEBP")
+	})
+	Register(CategoryFLAC, KindAudio, []string{".flac"}, func(h []byte) bool {
+		return len(h) >= 4 && h[0] == 0x66 && h[1] == 0x4C && h[2] == 0x61 && h[3] == 0x43
+	})
+	Register(CategoryOGG, KindAudio, []string{".ogg"}, func(h []byte) bool {
+		return len(h) >= 4 && h[0] == 0x4F && h[1] == 0x67 && h[2] == 0x67 && h[3] == 0x53
+	})
+	// AAC (ADTS sync FF F1/FF F9) must be checked before MP3, since its
+	// sync bits also satisfy MP3's more permissive FF?? & 0xE0 mask.
+	Register(CategoryAAC, KindAudio, []string{".aac"}, func(h []byte) bool {
+		return len(h) >= 2 && h[0] == 0xFF && (h[1] == 0xF1 || h[1] == 0xF9)
+	})
+	Register(CategoryMP3, KindAudio, []string{".mp3"}, func(h []byte) bool {
+		if len(h) < 3 {
+			return false
+		}
+		return (h[0] == 0xFF && (h[1]&0xE0) == 0xE0) ||
+			(h[0] == 0x49 && h[1] == 0x44 && h[2] == 0x33)
+	})
+	Register(CategoryAU, KindAudio, []string{".au"}, func(h []byte) bool {
+		return len(h) >= 4 && h[0] == 0x2E && h[1] == 0x73 && h[2] == 0x6E && h[3] == 0x64
+	})
+	Register(CategoryM4A, KindAudio, []string{".m4a", ".m4b"}, func(h []byte) bool {
+		return isFtyp(h, "M4A ", "M4B ", "M4P ")
+	})
+	Register(CategoryWMA, KindAudio, []string{".wma"}, func(h []byte) bool {
+		guid := [8]byte{0x30, 0x26, 0xB2, 0x75, 0x8E, 0x66, 0xCF, 0x11}
+		if len(h) < len(guid) {
+			return false
+		}
+		for i, b := range guid {
+			if h[i] != b {
+				return false
+			}
+		}
+		return true
+	})
+	Register(CategoryDSF, KindAudio, []string{".dsf"}, func(h []byte) bool {
+		return len(h) >= 4 && string(h[:4]) == "DSD "
+	})
+	Register(CategoryDFF, KindAudio, []string{".dff"}, func(h []byte) bool {
+		return len(h) >= 16 && string(h[:4]) == "FRM8" && string(h[12:16]) == "DSD "
+	})
+	Register(CategoryHEIC, KindImage, []string{".heic", ".heif"}, func(h []byte) bool {
+		return isFtyp(h, "heic", "heix", "heim", "heis", "hevc", "hevm", "hevs", "mif1", "msf1")
+	})
+	Register(CategoryAVIF, KindImage, []string{".avif"}, func(h []byte) bool {
+		return isFtyp(h, "avif", "avis")
+	})
+}
+
+// isFtyp reports whether header is an ISO base media "ftyp" box (used by
+// MP4-family containers: M4A, HEIC/HEIF, AVIF, ...) whose major brand is
+// one of brands.
+func isFtyp(header []byte, brands ...string) bool {
+	if len(header) < 12 {
+		return false
+	}
+	if string(header[4:8]) != "ftyp" {
+		return false
+	}
+	brand := string(header[8:12])
+	for _, b := range brands {
+		if brand == b {
+			return true
+		}
+	}
+	return false
+}
+
+// isRIFF reports whether header is a RIFF container with the given form type.
+func isRIFF(header []byte, form string) bool {
+	if len(header) < 12 {
+		return false
+	}
+	if header[0] != 0x52 || header[1] != 0x49 || header[2] != 0x46 || header[3] != 0x46 {
+		return false
+	}
+	return string(header[8:12]) == form
+}
+
 // FileInfo contains detected info about the target file
 type FileInfo struct {
 	Path      string
@@ -38,20 +189,12 @@ type FileInfo struct {
 
 // IsImage returns true if the file is an image
 func (f *FileInfo) IsImage() bool {
-	switch f.Category {
-	case CategoryPNG, CategoryJPG, CategoryBMP, CategoryGIF, CategoryTIFF, CategoryWEBP:
-		return true
-	}
-	return false
+	return kindRegistry[f.Category] == KindImage
 }
 
 // IsAudio returns true if the file is an audio file
 func (f *FileInfo) IsAudio() bool {
-	switch f.Category {
-	case CategoryWAV, CategoryMP3, CategoryFLAC, CategoryOGG, CategoryAU:
-		return true
-	}
-	return false
+	return kindRegistry[f.Category] == KindAudio
 }
 
 // Detect analyzes a file and returns its FileInfo
@@ -86,7 +229,7 @@ func Detect(filePath string) (*FileInfo, error) {
 	mimeType := http.DetectContentType(buf)
 	ext := strings.ToLower(filepath.Ext(absPath))
 
-	category := detectCategory(mimeType, ext, buf)
+	category := detectCategory(ext, buf)
 
 	return &FileInfo{
 		Path:      absPath,
@@ -98,83 +241,17 @@ func Detect(filePath string) (*FileInfo, error) {
 	}, nil
 }
 
-func detectCategory(mime, ext string, header []byte) FileCategory {
-	// Check magic bytes first for accuracy
-	if len(header) >= 8 {
-		// PNG: 89 50 4E 47
-		if header[0] == 0x89 && header[1] == 0x50 && header[2] == 0x4E && header[3] == 0x47 {
-			return CategoryPNG
-		}
-		// JPEG: FF D8 FF
-		if header[0] == 0xFF && header[1] == 0xD8 && header[2] == 0xFF {
-			return CategoryJPG
-		}
-		// BMP: 42 4D
-		if header[0] == 0x42 && header[1] == 0x4D {
-			return CategoryBMP
-		}
-		// GIF: 47 49 46 38
-		if header[0] == 0x47 && header[1] == 0x49 && header[2] == 0x46 && header[3] == 0x38 {
-			return CategoryGIF
-		}
-		// TIFF: 49 49 2A 00 or 4D 4D 00 2A
-		if (header[0] == 0x49 && header[1] == 0x49 && header[2] == 0x2A && header[3] == 0x00) ||
-			(header[0] == 0x4D && header[1] == 0x4D && header[2] == 0x00 && header[3] == 0x2A) {
-			return CategoryTIFF
-		}
-		// RIFF....WAVE (WAV)
-		if header[0] == 0x52 && header[1] == 0x49 && header[2] == 0x46 && header[3] == 0x46 &&
-			len(header) >= 12 && header[8] == 0x57 && header[9] == 0x41 && header[10] == 0x56 && header[11] == 0x45 {
-			return CategoryWAV
-		}
-		// RIFF....WEBP
-		if header[0] == 0x52 && header[1] == 0x49 && header[2] == 0x46 && header[3] == 0x46 &&
-			len(header) >= 12 && header[8] == 0x57 && header[9] == 0x45 && header[10] == 0x42 && header[11] == 0x50 {
-			return CategoryWEBP
-		}
-		// FLAC: 66 4C 61 43
-		if header[0] == 0x66 && header[1] == 0x4C && header[2] == 0x61 && header[3] == 0x43 {
-			return CategoryFLAC
-		}
-		// OGG: 4F 67 67 53
-		if header[0] == 0x4F && header[1] == 0x67 && header[2] == 0x67 && header[3] == 0x53 {
-			return CategoryOGG
-		}
-		// MP3: FF FB or FF F3 or FF F2 or ID3
-		if (header[0] == 0xFF && (header[1]&0xE0) == 0xE0) ||
-			(header[0] == 0x49 && header[1] == 0x44 && header[2] == 0x33) {
-			return CategoryMP3
-		}
-		// AU: 2E 73 6E 64
-		if header[0] == 0x2E && header[1] == 0x73 && header[2] == 0x6E && header[3] == 0x64 {
-			return CategoryAU
+// detectCategory runs every registered sniffer against the header, falling
+// back to the extension registry when none match.
+func detectCategory(ext string, header []byte) FileCategory {
+	for _, r := range snifferRegistry {
+		if r.sniffer(header) {
+			return r.category
 		}
 	}
 
-	// Fallback to extension-based detection
-	switch ext {
-	case ".png":
-		return CategoryPNG
-	case ".jpg", ".jpeg", ".jfif", ".jpe":
-		return CategoryJPG
-	case ".bmp":
-		return CategoryBMP
-	case ".gif":
-		return CategoryGIF
-	case ".tiff", ".tif":
-		return CategoryTIFF
-	case ".webp":
-		return CategoryWEBP
-	case ".wav":
-		return CategoryWAV
-	case ".mp3":
-		return CategoryMP3
-	case ".flac":
-		return CategoryFLAC
-	case ".ogg":
-		return CategoryOGG
-	case ".au":
-		return CategoryAU
+	if category, ok := extRegistry[ext]; ok {
+		return category
 	}
 
 	return CategoryUnknown
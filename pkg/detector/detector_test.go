@@ -0,0 +1,58 @@
+package detector
+
+import "testing"
+
+func TestDetectCategory(t *testing.T) {
+	ftyp := func(brand string) []byte {
+		h := make([]byte, 12)
+		copy(h[4:8], "ftyp")
+		copy(h[8:12], brand)
+		return h
+	}
+
+	tests := []struct {
+		name   string
+		header []byte
+		ext    string
+		want   FileCategory
+	}{
+		{"m4a", ftyp("M4A "), ".m4a", CategoryM4A},
+		{"wma", []byte{0x30, 0x26, 0xB2, 0x75, 0x8E, 0x66, 0xCF, 0x11}, ".wma", CategoryWMA},
+		{"aac", []byte{0xFF, 0xF1, 0x00, 0x00}, ".aac", CategoryAAC},
+		{"aac alt sync", []byte{0xFF, 0xF9, 0x00, 0x00}, ".aac", CategoryAAC},
+		{"mp3 still detected", []byte{0xFF, 0xFB, 0x00, 0x00}, ".mp3", CategoryMP3},
+		{"dsf", []byte("DSD \x00\x00\x00\x00"), ".dsf", CategoryDSF},
+		{"dff", append([]byte("FRM8\x00\x00\x00\x00\x00\x00\x00\x00"), []byte("DSD ")...), ".dff", CategoryDFF},
+		{"heic", ftyp("heic"), ".heic", CategoryHEIC},
+		{"avif", ftyp("avif"), ".avif", CategoryAVIF},
+		{"extension fallback", []byte{0x00, 0x00}, ".m4a", CategoryM4A},
+		{"unknown", []byte{0x00, 0x00}, ".xyz", CategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectCategory(tt.ext, tt.header)
+			if got != tt.want {
+				t.Errorf("detectCategory(%q, % x) = %s, want %s", tt.ext, tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAudioIsImage(t *testing.T) {
+	audio := &FileInfo{Category: CategoryDSF}
+	if !audio.IsAudio() {
+		t.Errorf("expected %s to classify as audio", CategoryDSF)
+	}
+	if audio.IsImage() {
+		t.Errorf("did not expect %s to classify as image", CategoryDSF)
+	}
+
+	img := &FileInfo{Category: CategoryAVIF}
+	if !img.IsImage() {
+		t.Errorf("expected %s to classify as image", CategoryAVIF)
+	}
+	if img.IsAudio() {
+		t.Errorf("did not expect %s to classify as audio", CategoryAVIF)
+	}
+}
@@ -0,0 +1,81 @@
+package imganalysis
+
+import "math"
+
+// chiSquarePValue returns P(X >= chiSquare) for X following a chi-square
+// distribution with df degrees of freedom, via the regularized upper
+// incomplete gamma function Q(df/2, chiSquare/2). The standard library has
+// no chi-square CDF, so this follows the classic Numerical Recipes
+// series/continued-fraction evaluation of the incomplete gamma function.
+func chiSquarePValue(chiSquare float64, df int) float64 {
+	if df <= 0 {
+		return 1
+	}
+	return upperIncompleteGammaQ(float64(df)/2, chiSquare/2)
+}
+
+const (
+	igammaIterations = 200
+	igammaEpsilon    = 3e-7
+	igammaTiny       = 1e-30
+)
+
+// upperIncompleteGammaQ computes Q(a, x) = 1 - P(a, x), switching between
+// the series expansion (for x < a+1) and the continued-fraction expansion
+// (otherwise) for numerical stability, as both converge slowly on the wrong
+// side of that boundary.
+func upperIncompleteGammaQ(a, x float64) float64 {
+	if x < 0 || a <= 0 {
+		return 0
+	}
+	if x < a+1 {
+		return 1 - lowerIncompleteGammaSeries(a, x)
+	}
+	return upperIncompleteGammaFraction(a, x)
+}
+
+func lowerIncompleteGammaSeries(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	gln, _ := math.Lgamma(a)
+	ap := a
+	sum := 1.0 / a
+	del := sum
+	for n := 0; n < igammaIterations; n++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*igammaEpsilon {
+			break
+		}
+	}
+	return sum * math.Exp(-x+a*math.Log(x)-gln)
+}
+
+func upperIncompleteGammaFraction(a, x float64) float64 {
+	gln, _ := math.Lgamma(a)
+	b := x + 1 - a
+	c := 1 / igammaTiny
+	d := 1 / b
+	h := d
+	for i := 1; i <= igammaIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < igammaTiny {
+			d = igammaTiny
+		}
+		c = b + an/c
+		if math.Abs(c) < igammaTiny {
+			c = igammaTiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < igammaEpsilon {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-gln) * h
+}
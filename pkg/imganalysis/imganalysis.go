@@ -0,0 +1,269 @@
+// Package imganalysis extracts and statistically analyzes an image's
+// bitplanes natively, replacing what used to be a shelled-out Python +
+// Pillow script.
+package imganalysis
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// PlaneReport is the analysis of one channel/bit bitplane.
+type PlaneReport struct {
+	Channel    string
+	Bit        int
+	Entropy    float64 // Shannon entropy of the plane, in [0, 1]
+	Suspicious bool    // entropy sits near 1.0, i.e. the plane looks close to random
+	File       string  // path to the written black-and-white PNG
+}
+
+// ChannelStats is the chi-square LSB-of-pairs test (Westfeld-Pfitzmann) for
+// one channel as a whole.
+type ChannelStats struct {
+	Channel    string
+	ChiSquare  float64
+	PValue     float64 // P(X >= ChiSquare) under a chi-square null with one fewer degree of freedom than non-empty value pairs
+	Suspicious bool    // PValue close to 1: pair frequencies are "too equal" to be natural
+}
+
+// Report is the full result of analyzing one image.
+type Report struct {
+	Mode     string // "rgba", "cmyk", or "gray"
+	Width    int
+	Height   int
+	Channels []ChannelStats
+	Planes   []PlaneReport
+}
+
+// entropySuspiciousThreshold flags a bitplane as suspicious when its Shannon
+// entropy sits within this distance of 1.0 (maximal, coin-flip randomness).
+const entropySuspiciousThreshold = 0.05
+
+// pValueSuspiciousThreshold flags a channel as suspicious when its
+// chi-square p-value exceeds this — i.e. the observed value-pair
+// frequencies are statistically indistinguishable from the perfectly equal
+// distribution that LSB replacement produces.
+const pValueSuspiciousThreshold = 0.95
+
+// Analyze decodes the image at inPath, extracts every bitplane of its
+// channels, writes each as a black-and-white PNG under outDir, and computes per-plane
+// entropy plus a per-channel chi-square LSB-of-pairs statistic.
+func Analyze(inPath, outDir string) (*Report, error) {
+	f, err := os.Open(inPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+
+	var mode string
+	var channels []namedChannel
+	switch src := img.(type) {
+	case *image.CMYK:
+		mode = "cmyk"
+		channels = cmykChannels(src)
+	case *image.Gray:
+		mode = "gray"
+		channels = grayChannels(src)
+	case *image.Gray16:
+		mode = "gray"
+		channels = gray16Channels(src)
+	default:
+		mode = "rgba"
+		rgba := image.NewRGBA(bounds)
+		draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+		channels = rgbaChannels(rgba)
+	}
+
+	report := &Report{Mode: mode, Width: bounds.Dx(), Height: bounds.Dy()}
+
+	for _, ch := range channels {
+		values := sampleChannel(bounds, ch.at)
+
+		chi2, p := chiSquareLSBPairs(values)
+		stats := ChannelStats{
+			Channel:    ch.name,
+			ChiSquare:  chi2,
+			PValue:     p,
+			Suspicious: p > pValueSuspiciousThreshold,
+		}
+		report.Channels = append(report.Channels, stats)
+
+		for bit := 0; bit < 8; bit++ {
+			plane := bitplaneImage(bounds, values, bit)
+			ent := bitEntropy(values, bit)
+
+			fileName := fmt.Sprintf("%s_bit%d.png", ch.name, bit)
+			outPath := filepath.Join(outDir, fileName)
+			if err := writePNG(outPath, plane); err != nil {
+				return nil, fmt.Errorf("write %s: %w", fileName, err)
+			}
+
+			report.Planes = append(report.Planes, PlaneReport{
+				Channel:    ch.name,
+				Bit:        bit,
+				Entropy:    ent,
+				Suspicious: math.Abs(ent-1.0) < entropySuspiciousThreshold,
+				File:       outPath,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// namedChannel extracts one 8-bit channel's value at (x, y), in whatever
+// color model the source image uses.
+type namedChannel struct {
+	name string
+	at   func(x, y int) uint8
+}
+
+func rgbaChannels(img *image.RGBA) []namedChannel {
+	return []namedChannel{
+		{"Red", func(x, y int) uint8 { return img.RGBAAt(x, y).R }},
+		{"Green", func(x, y int) uint8 { return img.RGBAAt(x, y).G }},
+		{"Blue", func(x, y int) uint8 { return img.RGBAAt(x, y).B }},
+		{"Alpha", func(x, y int) uint8 { return img.RGBAAt(x, y).A }},
+	}
+}
+
+func cmykChannels(img *image.CMYK) []namedChannel {
+	return []namedChannel{
+		{"Cyan", func(x, y int) uint8 { return img.CMYKAt(x, y).C }},
+		{"Magenta", func(x, y int) uint8 { return img.CMYKAt(x, y).M }},
+		{"Yellow", func(x, y int) uint8 { return img.CMYKAt(x, y).Y }},
+		{"Key", func(x, y int) uint8 { return img.CMYKAt(x, y).K }},
+	}
+}
+
+func grayChannels(img *image.Gray) []namedChannel {
+	return []namedChannel{
+		{"Gray", func(x, y int) uint8 { return img.GrayAt(x, y).Y }},
+	}
+}
+
+func gray16Channels(img *image.Gray16) []namedChannel {
+	return []namedChannel{
+		{"Gray", func(x, y int) uint8 { return uint8(img.Gray16At(x, y).Y >> 8) }},
+	}
+}
+
+// sampleChannel reads one channel's values in row-major order.
+func sampleChannel(bounds image.Rectangle, at func(x, y int) uint8) []uint8 {
+	values := make([]uint8, 0, bounds.Dx()*bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			values = append(values, at(x, y))
+		}
+	}
+	return values
+}
+
+// bitplaneImage renders one bit of a channel's values as a black/white image.
+func bitplaneImage(bounds image.Rectangle, values []uint8, bit int) *image.Gray {
+	plane := image.NewGray(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	idx := 0
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			v := (values[idx] >> uint(bit)) & 1
+			idx++
+			g := uint8(0)
+			if v == 1 {
+				g = 255
+			}
+			plane.SetGray(x, y, color.Gray{Y: g})
+		}
+	}
+	return plane
+}
+
+// bitEntropy computes the Shannon entropy, in bits, of the given bit across
+// all of a channel's values. A uniform 50/50 split of 0s and 1s gives an
+// entropy of 1.0; an all-0 or all-1 plane gives 0.
+func bitEntropy(values []uint8, bit int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	ones := 0
+	for _, v := range values {
+		if (v>>uint(bit))&1 == 1 {
+			ones++
+		}
+	}
+	p1 := float64(ones) / float64(len(values))
+	p0 := 1 - p1
+	return entropyTerm(p0) + entropyTerm(p1)
+}
+
+func entropyTerm(p float64) float64 {
+	if p <= 0 {
+		return 0
+	}
+	return -p * math.Log2(p)
+}
+
+// chiSquareLSBPairs runs the Westfeld-Pfitzmann chi-square LSB-of-pairs test
+// on a channel's values. It groups the 256 possible values into 128 pairs
+// {2k, 2k+1} and compares their observed frequencies against the equal
+// split LSB replacement would produce.
+func chiSquareLSBPairs(values []uint8) (chiSquare, pValue float64) {
+	var counts [256]int
+	for _, v := range values {
+		counts[v]++
+	}
+
+	const pairs = 128
+	chi2 := 0.0
+	df := 0
+	for k := 0; k < pairs; k++ {
+		a := float64(counts[2*k])
+		b := float64(counts[2*k+1])
+		total := a + b
+		if total == 0 {
+			continue
+		}
+		expected := total / 2
+		chi2 += (a-expected)*(a-expected)/expected + (b-expected)*(b-expected)/expected
+		df++
+	}
+	if df == 0 {
+		return 0, 1
+	}
+	df--
+	if df == 0 {
+		return chi2, 1
+	}
+	return chi2, chiSquarePValue(chi2, df)
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, img)
+}
+
+// image/jpeg is only imported for its format-registering init(), so
+// image.Decode can read JPEG source files; reference it so the import isn't
+// flagged unused.
+var _ = jpeg.Decode
@@ -2,6 +2,7 @@ package output
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -30,13 +31,32 @@ var (
 
 // Result represents the output of a single tool run
 type Result struct {
-	ToolName  string
-	Category  string
-	Output    string
-	Error     error
-	Duration  time.Duration
-	Skipped   bool
+	ToolName   string
+	Category   string
+	Output     string
+	Error      error
+	Duration   time.Duration
+	Skipped    bool
 	SkipReason string
+
+	// File is the path of the file this tool ran against. For a
+	// top-level scan it's the file the user asked to scan; for an
+	// artifact discovered during recursive carving, it's that artifact.
+	File string
+
+	// Provenance records how File was discovered, for results produced
+	// by recursive carving. Nil for the top-level file being scanned.
+	Provenance *Provenance
+}
+
+// Provenance describes how a recursively-discovered artifact came to be
+// scanned: the file it was carved out of, which tool did the carving, and
+// how many carving hops deep it is (1 = extracted directly from the
+// top-level file, 2 = extracted from something extracted from it, etc).
+type Provenance struct {
+	ParentFile string
+	Tool       string
+	Depth      int
 }
 
 // PrintBanner prints the ASCII art banner
@@ -88,7 +108,10 @@ func PrintCategoryHeader(category string) {
 	magenta.Printf("%s\n", strings.Repeat("─", 55-len(category)))
 }
 
-// PrintToolResult prints a single tool's result
+// PrintToolResult prints a single tool's result. Results carved out of a
+// parent file during recursive scanning (result.Provenance != nil) are
+// indented one level per carving hop and prefixed with where they came
+// from, so the tree of extracted artifacts is visible at a glance.
 func PrintToolResult(result *Result) {
 	green := color.New(color.FgGreen, color.Bold)
 	red := color.New(color.FgRed)
@@ -97,14 +120,20 @@ func PrintToolResult(result *Result) {
 	gray := color.New(color.FgHiBlack)
 	white := color.New(color.FgWhite)
 
+	indent := ""
+	if result.Provenance != nil {
+		indent = strings.Repeat("    ", result.Provenance.Depth)
+		gray.Printf("  │%s  ↳ carved by %s from %s\n", indent, result.Provenance.Tool, filepath.Base(result.Provenance.ParentFile))
+	}
+
 	if result.Skipped {
-		gray.Printf("  │ ⊘ %-18s", result.ToolName)
+		gray.Printf("  │%s ⊘ %-18s", indent, result.ToolName)
 		gray.Printf("skipped: %s\n", result.SkipReason)
 		return
 	}
 
 	if result.Error != nil {
-		red.Printf("  │ ✗ %-18s", result.ToolName)
+		red.Printf("  │%s ✗ %-18s", indent, result.ToolName)
 		gray.Printf("(%s) ", result.Duration.Round(time.Millisecond))
 		red.Printf("error: %v\n", result.Error)
 		return
@@ -112,13 +141,13 @@ func PrintToolResult(result *Result) {
 
 	output := strings.TrimSpace(result.Output)
 	if output == "" {
-		gray.Printf("  │ ○ %-18s", result.ToolName)
+		gray.Printf("  │%s ○ %-18s", indent, result.ToolName)
 		gray.Printf("(%s) ", result.Duration.Round(time.Millisecond))
 		gray.Println("no output")
 		return
 	}
 
-	green.Printf("  │ ✓ %-18s", result.ToolName)
+	green.Printf("  │%s ✓ %-18s", indent, result.ToolName)
 	cyan.Printf("(%s)\n", result.Duration.Round(time.Millisecond))
 
 	lines := strings.Split(output, "\n")
@@ -126,10 +155,10 @@ func PrintToolResult(result *Result) {
 
 	for i, line := range lines {
 		if i >= maxLines {
-			yellow.Printf("  │   ... and %d more lines\n", len(lines)-maxLines)
+			yellow.Printf("  │%s   ... and %d more lines\n", indent, len(lines)-maxLines)
 			break
 		}
-		white.Printf("  │   %s\n", line)
+		white.Printf("  │%s   %s\n", indent, line)
 	}
 }
 
@@ -190,12 +219,6 @@ func PrintSummary(results []*Result, totalDuration time.Duration) {
 	fmt.Println()
 }
 
-// PrintProgress prints a progress update during scanning
-func PrintProgress(toolName string, current, total int) {
-	cyan := color.New(color.FgCyan)
-	cyan.Printf("\r  ⏳ [%d/%d] Running %s...                    ", current, total, toolName)
-}
-
 // PrintDepsNotice prints notice about missing tools
 func PrintDepsNotice(missing []string) {
 	yellow := color.New(color.FgYellow)
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ProgressView renders a live, multi-line list of the tools currently
+// running, redrawing in place via ANSI cursor movement as tools start and
+// finish. One ProgressView is meant to be shared across a whole scan,
+// including whatever it recurses into, so it reflects every tool active
+// anywhere in the carving tree at once rather than just the top-level
+// file's.
+type ProgressView struct {
+	mu     sync.Mutex
+	active map[string]bool
+	lines  int // lines drawn on screen by the last render, for clearing
+}
+
+// NewProgressView returns an empty ProgressView ready to render.
+func NewProgressView() *ProgressView {
+	return &ProgressView{active: make(map[string]bool)}
+}
+
+// Start marks name as running and redraws.
+func (p *ProgressView) Start(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active[name] = true
+	p.render()
+}
+
+// Done marks name as finished and redraws.
+func (p *ProgressView) Done(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.active, name)
+	p.render()
+}
+
+// Finish clears whatever the view last drew, once the scan is done.
+func (p *ProgressView) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active = make(map[string]bool)
+	p.render()
+}
+
+// render rewrites the previously-drawn lines in place: move the cursor up
+// over them, print one "⏳ Running X..." line per active tool (sorted for a
+// stable order frame to frame), and blank out anything left over from a
+// longer previous frame. Must be called with mu held.
+func (p *ProgressView) render() {
+	if p.lines > 0 {
+		fmt.Printf("\x1b[%dA", p.lines)
+	}
+
+	names := make([]string, 0, len(p.active))
+	for name := range p.active {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("\x1b[2K  ⏳ Running %s...\n", name)
+	}
+	for i := len(names); i < p.lines; i++ {
+		fmt.Printf("\x1b[2K\n")
+	}
+	if len(names) < p.lines {
+		fmt.Printf("\x1b[%dA", p.lines-len(names))
+	}
+
+	p.lines = len(names)
+}
@@ -0,0 +1,316 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// reportSchemaVersion is bumped whenever a field is added or removed from
+// the JSON reporter's per-line records, so a consumer can tell which shape
+// it's looking at.
+const reportSchemaVersion = 1
+
+// FileReport is the subset of detector.FileInfo needed to render a report,
+// duplicated here (rather than imported) so that output does not depend on
+// the detector package.
+type FileReport struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	MimeType string `json:"mime_type"`
+	Category string `json:"category"`
+	Size     int64  `json:"size"`
+}
+
+// Reporter renders a scan as it happens: one FileHeader call up front, one
+// ToolResult call per completed tool (in whatever order they finish), one
+// Summary call once the scan is done, and Close to release any resources
+// (an --output-file handle, a buffered writer). Every implementation must
+// tolerate Close being called even if the scan errored out before Summary.
+type Reporter interface {
+	FileHeader(file FileReport)
+	ToolResult(result *Result)
+	Summary(duration time.Duration)
+	Close() error
+}
+
+// NewReporter returns the Reporter for the named --format, writing to w.
+// "json" and "sarif" write to w directly; "pretty" ignores w and uses the
+// existing colorized Print* functions on stdout.
+func NewReporter(format string, w io.Writer) (Reporter, error) {
+	switch format {
+	case "pretty":
+		return NewPrettyReporter(), nil
+	case "json":
+		return NewJSONReporter(w), nil
+	case "sarif":
+		return NewSarifReporter(w), nil
+	default:
+		return nil, &unknownFormatError{format}
+	}
+}
+
+type unknownFormatError struct{ format string }
+
+func (e *unknownFormatError) Error() string {
+	return "unknown report format " + "\"" + e.format + "\"" + " (want pretty, json, or sarif)"
+}
+
+// PrettyReporter buffers a file's tool results and renders them with the
+// existing colorized Print* functions once the scan is done, grouped by
+// category the same way PrintResults always has.
+type PrettyReporter struct {
+	results []*Result
+}
+
+// NewPrettyReporter returns a Reporter that drives the ANSI console output.
+func NewPrettyReporter() *PrettyReporter {
+	return &PrettyReporter{}
+}
+
+func (p *PrettyReporter) FileHeader(file FileReport) {
+	PrintFileInfo(file.Name, file.Path, file.MimeType, file.Size, file.Category)
+}
+
+func (p *PrettyReporter) ToolResult(result *Result) {
+	p.results = append(p.results, result)
+}
+
+func (p *PrettyReporter) Summary(duration time.Duration) {
+	categories := []string{"general", "image", "audio", "text"}
+	categoryNames := map[string]string{
+		"general": "🔧 General Analysis",
+		"image":   "🖼️  Image Steganography",
+		"audio":   "🎵 Audio Steganography",
+		"text":    "📝 Text / Misc Steganography",
+	}
+
+	for _, cat := range categories {
+		var catResults []*Result
+		for _, r := range p.results {
+			if r != nil && r.Category == cat {
+				catResults = append(catResults, r)
+			}
+		}
+		if len(catResults) == 0 {
+			continue
+		}
+
+		PrintCategoryHeader(categoryNames[cat])
+		for _, r := range catResults {
+			PrintToolResult(r)
+		}
+	}
+
+	PrintSummary(p.results, duration)
+}
+
+func (p *PrettyReporter) Close() error { return nil }
+
+// jsonReporter streams one newline-delimited JSON object per call: a "file"
+// record from FileHeader, a "result" record per ToolResult, and a "summary"
+// record to close out. Consumers can start processing before the scan
+// finishes instead of waiting for one big blob.
+type jsonReporter struct {
+	enc  *json.Encoder
+	file string
+	n    int
+}
+
+// NewJSONReporter returns a Reporter that writes schema-versioned NDJSON to w.
+func NewJSONReporter(w io.Writer) Reporter {
+	return &jsonReporter{enc: json.NewEncoder(w)}
+}
+
+type jsonFileRecord struct {
+	Type          string     `json:"type"`
+	SchemaVersion int        `json:"schema_version"`
+	File          FileReport `json:"file"`
+}
+
+func (j *jsonReporter) FileHeader(file FileReport) {
+	j.file = file.Path
+	j.enc.Encode(jsonFileRecord{Type: "file", SchemaVersion: reportSchemaVersion, File: file})
+}
+
+type jsonResultRecord struct {
+	Type          string      `json:"type"`
+	SchemaVersion int         `json:"schema_version"`
+	File          string      `json:"file"`
+	Tool          string      `json:"tool"`
+	Category      string      `json:"category"`
+	Output        string      `json:"output,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	Skipped       bool        `json:"skipped,omitempty"`
+	SkipReason    string      `json:"skip_reason,omitempty"`
+	DurationMs    int64       `json:"duration_ms"`
+	Provenance    *Provenance `json:"provenance,omitempty"`
+}
+
+func (j *jsonReporter) ToolResult(result *Result) {
+	j.n++
+	rec := jsonResultRecord{
+		Type:          "result",
+		SchemaVersion: reportSchemaVersion,
+		File:          result.File,
+		Tool:          result.ToolName,
+		Category:      result.Category,
+		Output:        result.Output,
+		Skipped:       result.Skipped,
+		SkipReason:    result.SkipReason,
+		DurationMs:    result.Duration.Milliseconds(),
+		Provenance:    result.Provenance,
+	}
+	if result.Error != nil {
+		rec.Error = result.Error.Error()
+	}
+	j.enc.Encode(rec)
+}
+
+type jsonSummaryRecord struct {
+	Type          string `json:"type"`
+	SchemaVersion int    `json:"schema_version"`
+	File          string `json:"file"`
+	Total         int    `json:"total"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+func (j *jsonReporter) Summary(duration time.Duration) {
+	j.enc.Encode(jsonSummaryRecord{
+		Type:          "summary",
+		SchemaVersion: reportSchemaVersion,
+		File:          j.file,
+		Total:         j.n,
+		DurationMs:    duration.Milliseconds(),
+	})
+}
+
+func (j *jsonReporter) Close() error { return nil }
+
+// sarifReporter buffers the whole scan and emits a single SARIF 2.1.0 log
+// on Close, since SARIF is one JSON document rather than a stream.
+type sarifReporter struct {
+	w       io.Writer
+	file    FileReport
+	results []*Result
+}
+
+// NewSarifReporter returns a Reporter that writes a SARIF 2.1.0 log to w.
+func NewSarifReporter(w io.Writer) Reporter {
+	return &sarifReporter{w: w}
+}
+
+func (s *sarifReporter) FileHeader(file FileReport) { s.file = file }
+
+func (s *sarifReporter) ToolResult(result *Result) {
+	s.results = append(s.results, result)
+}
+
+func (s *sarifReporter) Summary(duration time.Duration) {}
+
+func (s *sarifReporter) Close() error {
+	enc := json.NewEncoder(s.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(buildSarifLog(s.file, s.results))
+}
+
+// SARIF 2.1.0 types, kept minimal to what this tool actually emits.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	InformationUri string `json:"informationUri,omitempty"`
+}
+
+type sarifResult struct {
+	RuleId           string          `json:"ruleId"`
+	Level            string          `json:"level"`
+	Kind             string          `json:"kind,omitempty"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+const sarifSchemaUri = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// buildSarifLog turns a finished scan into a SARIF log with one run per
+// non-skipped result with non-empty output, so each tool surfaces as its own
+// driver (a tool that ran on several carved files gets one run per file). A result is marked kind=review (rather than informational) when
+// its output contains a "[!]" marker, the convention some of the built-in
+// tool scripts already use to flag a likely hit. A carved result's
+// relatedLocations points back at the file it was carved out of.
+func buildSarifLog(file FileReport, results []*Result) sarifLog {
+	log := sarifLog{Schema: sarifSchemaUri, Version: "2.1.0"}
+
+	for _, r := range results {
+		if r == nil || r.Skipped || strings.TrimSpace(r.Output) == "" {
+			continue
+		}
+
+		artifactPath := r.File
+		if artifactPath == "" {
+			artifactPath = file.Path
+		}
+
+		kind := "informational"
+		if strings.Contains(r.Output, "[!]") {
+			kind = "review"
+		}
+
+		result := sarifResult{
+			RuleId:  r.ToolName,
+			Level:   "note",
+			Kind:    kind,
+			Message: sarifMessage{Text: r.Output},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{Uri: artifactPath},
+				},
+			}},
+		}
+		if r.Provenance != nil {
+			result.RelatedLocations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{Uri: r.Provenance.ParentFile},
+				},
+			}}
+		}
+
+		log.Runs = append(log.Runs, sarifRun{
+			Tool:    sarifTool{Driver: sarifDriver{Name: r.ToolName}},
+			Results: []sarifResult{result},
+		})
+	}
+
+	return log
+}
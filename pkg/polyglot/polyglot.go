@@ -0,0 +1,323 @@
+// Package polyglot looks for a second archive stream appended past the end
+// of a host image or audio file — a common way to hide a payload inside a
+// PNG/JPEG/WAV without breaking the file as far as any ordinary viewer is
+// concerned. It replaces what used to require shelling out to binwalk or
+// foremost for this one specific trick.
+package polyglot
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Finding describes one archive signature discovered past the host file's
+// declared end of data.
+type Finding struct {
+	Format        string   // "zip", "tar", "gzip", "7z", "rar", or "bzip2"
+	Offset        int64    // byte offset the archive signature starts at
+	TrailingBytes int64    // bytes from Offset to the end of the file
+	Entries       []string // member names, for formats we can enumerate (zip, tar)
+}
+
+type signature struct {
+	format string
+	magic  []byte
+}
+
+// signatures are checked in order at every offset; zip's two magic values
+// (local file header and end-of-central-directory) both map to "zip" since
+// either one marks the start of an appended zip stream worth reporting.
+var signatures = []signature{
+	{"zip", []byte("PK\x03\x04")},
+	{"zip", []byte("PK\x05\x06")},
+	{"gzip", []byte{0x1f, 0x8b}},
+	{"7z", []byte{0x37, 0x7A, 0xBC, 0xAF, 0x27, 0x1C}},
+	{"rar", []byte("Rar!")},
+	{"bzip2", []byte("BZh")},
+}
+
+// tarMagicOffset and tarMagicLen locate the "ustar" magic within a POSIX
+// tar header block, which starts wherever the embedded tar stream begins
+// in the host file (not necessarily 512-byte aligned within it).
+const (
+	tarMagicOffset = 257
+	tarMagicLen    = 5
+)
+
+// Scan reads path and reports every archive signature found at or after the
+// host format's declared end of data (the PNG IEND chunk, the JPEG EOI
+// marker, or a WAV's RIFF chunk size). A host format Scan doesn't recognize
+// has no declared end, so every signature in the file counts as trailing.
+// Only the first occurrence of each format is reported, since a real
+// embedded archive's own internal structure (a zip's central directory, a
+// tar's per-member headers) would otherwise reappear as spurious findings.
+func Scan(path string) ([]Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	start := int(hostEnd(data))
+	if start >= len(data) {
+		return nil, nil
+	}
+
+	var findings []Finding
+	seen := make(map[string]bool)
+	for off := start; off < len(data); off++ {
+		format := matchSignature(data, off)
+		if format == "" || seen[format] {
+			continue
+		}
+		seen[format] = true
+		findings = append(findings, buildFinding(data, format, int64(off)))
+	}
+	return findings, nil
+}
+
+func matchSignature(data []byte, off int) string {
+	for _, sig := range signatures {
+		if bytes.HasPrefix(data[off:], sig.magic) {
+			return sig.format
+		}
+	}
+	if off+tarMagicOffset+tarMagicLen <= len(data) &&
+		bytes.Equal(data[off+tarMagicOffset:off+tarMagicOffset+tarMagicLen], []byte("ustar")) {
+		return "tar"
+	}
+	return ""
+}
+
+// buildFinding fills in Entries for formats the standard library can
+// enumerate without decompressing anything (zip, tar). gzip, 7z, rar, and
+// bzip2 are reported by signature and offset alone.
+func buildFinding(data []byte, format string, offset int64) Finding {
+	f := Finding{Format: format, Offset: offset, TrailingBytes: int64(len(data)) - offset}
+
+	section := io.NewSectionReader(bytes.NewReader(data), offset, f.TrailingBytes)
+	switch format {
+	case "zip":
+		if zr, err := zip.NewReader(section, f.TrailingBytes); err == nil {
+			for _, file := range zr.File {
+				f.Entries = append(f.Entries, file.Name)
+			}
+		}
+	case "tar":
+		tr := tar.NewReader(section)
+		for {
+			hdr, err := tr.Next()
+			if err != nil {
+				break
+			}
+			f.Entries = append(f.Entries, hdr.Name)
+		}
+	}
+	return f
+}
+
+// Extract writes every member of an enumerable finding (zip, tar) under
+// outDir and returns the paths written. Formats polyglot can only detect by
+// signature (gzip, 7z, rar, bzip2) have no member list to extract here, so
+// Extract returns nil for those — Scan already reported their offset.
+func Extract(path string, finding Finding, outDir string) ([]string, error) {
+	if finding.Format != "zip" && finding.Format != "tar" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, err
+	}
+
+	trailing := int64(len(data)) - finding.Offset
+	section := io.NewSectionReader(bytes.NewReader(data), finding.Offset, trailing)
+
+	var written []string
+	switch finding.Format {
+	case "zip":
+		zr, err := zip.NewReader(section, trailing)
+		if err != nil {
+			return nil, fmt.Errorf("open embedded zip: %w", err)
+		}
+		for _, file := range zr.File {
+			out := filepath.Join(outDir, filepath.Base(file.Name))
+			if err := extractZipEntry(file, out); err != nil {
+				return written, err
+			}
+			written = append(written, out)
+		}
+	case "tar":
+		tr := tar.NewReader(section)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return written, fmt.Errorf("read embedded tar: %w", err)
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			out := filepath.Join(outDir, filepath.Base(hdr.Name))
+			f, err := os.Create(out)
+			if err != nil {
+				return written, err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return written, err
+			}
+			written = append(written, out)
+		}
+	}
+	return written, nil
+}
+
+func extractZipEntry(file *zip.File, out string) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, rc)
+	return err
+}
+
+// hostEnd returns the offset immediately past the host format's declared
+// end of data, or 0 if data isn't a format this package knows how to bound
+// (in which case every signature match in the file counts as trailing).
+func hostEnd(data []byte) int64 {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return pngEnd(data)
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return jpegEnd(data)
+	case len(data) >= 12 && bytes.HasPrefix(data, []byte("RIFF")) && string(data[8:12]) == "WAVE":
+		return riffEnd(data)
+	default:
+		return 0
+	}
+}
+
+// pngEnd walks PNG chunks from the signature to the IEND chunk and returns
+// the offset right after its CRC, i.e. where a PNG viewer stops reading.
+func pngEnd(data []byte) int64 {
+	pos := int64(8)
+	for pos+8 <= int64(len(data)) {
+		length := int64(data[pos])<<24 | int64(data[pos+1])<<16 | int64(data[pos+2])<<8 | int64(data[pos+3])
+		chunkType := string(data[pos+4 : pos+8])
+		next := pos + 8 + length + 4 // length field + type + data + CRC
+		if chunkType == "IEND" {
+			return next
+		}
+		if next <= pos || next > int64(len(data)) {
+			break
+		}
+		pos = next
+	}
+	return 0
+}
+
+// jpegEnd walks JPEG markers forward from the SOI the same way pngEnd walks
+// PNG chunks, stopping at the first real EOI it reaches, and returns the
+// offset right after it. It used to find the *last* FF D9 in the file via a
+// reverse byte search, but entropy-coded scan data (and anything an attacker
+// appends after it) is free-form bytes that can easily contain an FF D9 pair
+// of their own — a trailing payload planted right after the real EOI would
+// then get hidden behind that later, coincidental match. Forward-parsing the
+// structure instead of pattern-matching raw bytes is the only way to find
+// the *real* end.
+func jpegEnd(data []byte) int64 {
+	n := int64(len(data))
+	if n < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0
+	}
+
+	pos := int64(2)
+	for pos+1 < n {
+		if data[pos] != 0xFF {
+			return 0 // not aligned on a marker; give up rather than guess
+		}
+		marker := data[pos+1]
+		for marker == 0xFF && pos+1 < n { // markers may be padded with extra FF fill bytes
+			pos++
+			if pos+1 >= n {
+				return 0
+			}
+			marker = data[pos+1]
+		}
+		pos += 2
+
+		switch {
+		case marker == 0xD9: // EOI
+			return pos
+		case marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// SOI/TEM/RSTn: standalone, no length field follows
+			continue
+		case marker == 0xDA: // SOS: header, then entropy-coded scan data
+			if pos+2 > n {
+				return 0
+			}
+			length := int64(data[pos])<<8 | int64(data[pos+1])
+			pos += length
+			for pos+1 < n {
+				if data[pos] != 0xFF {
+					pos++
+					continue
+				}
+				next := data[pos+1]
+				if next == 0x00 || (next >= 0xD0 && next <= 0xD7) {
+					// byte-stuffed FF or a restart marker: part of the scan
+					pos += 2
+					continue
+				}
+				break // a real marker follows; let the outer loop handle it
+			}
+		default:
+			if pos+2 > n {
+				return 0
+			}
+			length := int64(data[pos])<<8 | int64(data[pos+1])
+			if length < 2 {
+				return 0
+			}
+			pos += length
+		}
+		if pos > n {
+			return 0
+		}
+	}
+	return 0
+}
+
+// riffEnd returns the offset right after a RIFF/WAVE file's declared chunk
+// size, or 0 if that size doesn't fit within the file (so hostEnd falls
+// back to treating every signature as trailing).
+func riffEnd(data []byte) int64 {
+	if len(data) < 8 {
+		return 0
+	}
+	size := int64(data[4]) | int64(data[5])<<8 | int64(data[6])<<16 | int64(data[7])<<24
+	end := 8 + size
+	if end > int64(len(data)) {
+		return 0
+	}
+	return end
+}
@@ -0,0 +1,52 @@
+package polyglot
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildMinimalJPEG returns a tiny but structurally valid JPEG: SOI, a DQT
+// segment, SOS with scan data containing a byte-stuffed FF 00 (never a real
+// marker), then the real EOI.
+func buildMinimalJPEG() []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xFF, 0xD8})                         // SOI
+	b.Write([]byte{0xFF, 0xDB, 0x00, 0x04, 0xAA, 0xBB}) // DQT, length 4 (incl. length bytes), 2-byte payload
+	b.Write([]byte{0xFF, 0xDA, 0x00, 0x04, 0x01, 0x00}) // SOS header, length 4
+	// Entropy-coded scan data, with a stuffed FF 00 in the middle (never a
+	// real marker, just how FF is escaped inside scan data).
+	b.Write([]byte{0x11, 0x22, 0xFF, 0x00, 0x33, 0x44})
+	b.Write([]byte{0xFF, 0xD9}) // real EOI
+	return b.Bytes()
+}
+
+// TestJpegEndSkipsSpuriousMarkerInAppendedPayload guards against a reverse
+// byte search over attacker-controlled trailing bytes: if a payload appended
+// after a real JPEG happens to contain its own FF D9 pair (plausible in any
+// compressed/encrypted data of nontrivial size), jpegEnd must not stop there
+// instead of at the real EOI.
+func TestJpegEndSkipsSpuriousMarkerInAppendedPayload(t *testing.T) {
+	jpeg := buildMinimalJPEG()
+	trailing := []byte{0x50, 0x4B, 0x03, 0x04, 0xAA, 0xBB, 0xFF, 0xD9, 0xCC, 0xDD}
+	data := append(append([]byte{}, jpeg...), trailing...)
+
+	if got, want := jpegEnd(data), int64(len(jpeg)); got != want {
+		t.Fatalf("jpegEnd = %d, want %d", got, want)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.jpg")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := Scan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].Format != "zip" {
+		t.Fatalf("expected one zip finding past the real EOI, got %+v", findings)
+	}
+}
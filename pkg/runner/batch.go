@@ -0,0 +1,162 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/canyouhack/steg-cli/pkg/detector"
+)
+
+// BatchOpts configures a directory scan. RunOpts carries the same
+// per-tool options used by a single-file scan.
+type BatchOpts struct {
+	RunOpts
+	Jobs        int      // worker pool size, default runtime.NumCPU()
+	Include     []string // glob patterns a file's base name must match, if set
+	Exclude     []string // glob patterns a file's base name must not match
+	SkipUnknown bool     // skip files detector couldn't classify
+}
+
+// BatchResult is the outcome of scanning a single file discovered during a
+// batch run.
+type BatchResult struct {
+	Path  string
+	Hash  string // sha256 of the file contents, used for report dedup
+	Scan  *ScanResult
+	Error error
+}
+
+// BatchRun walks root, dispatching every matching file to a pool of workers
+// that detect its type and run the full tool pipeline against it. It mirrors
+// the Source -> Parse -> Process channel pipeline used elsewhere in this
+// codebase: one goroutine walks the tree producing paths, a worker pool
+// consumes them, and this function collects results as they complete.
+func BatchRun(root string, opts BatchOpts) []*BatchResult {
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	SetToolConcurrency(jobs * 4)
+
+	paths := make(chan string)
+	results := make(chan *BatchResult)
+
+	go func() {
+		defer close(paths)
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			if !matchesFilters(filepath.Base(path), opts.Include, opts.Exclude) {
+				return nil
+			}
+			paths <- path
+			return nil
+		})
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				results <- scanBatchFile(path, opts)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var collected []*BatchResult
+	for r := range results {
+		writeBatchReport(r, opts.OutputDir)
+		collected = append(collected, r)
+	}
+	return collected
+}
+
+// scanBatchFile detects and scans a single file for BatchRun.
+func scanBatchFile(path string, opts BatchOpts) *BatchResult {
+	result := &BatchResult{Path: path}
+
+	if hash, err := hashFile(path); err == nil {
+		result.Hash = hash
+	}
+
+	fileInfo, err := detector.Detect(path)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	if opts.SkipUnknown && fileInfo.Category == detector.CategoryUnknown {
+		return result
+	}
+
+	result.Scan = RunAll(fileInfo, opts.RunOpts)
+	return result
+}
+
+// matchesFilters reports whether name satisfies the include/exclude glob
+// filters. An empty include list matches everything.
+func matchesFilters(name string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hashFile returns the hex-encoded sha256 of a file's contents.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeBatchReport writes a single file's scan result as JSON under
+// outputDir/<sha256>/report.json, if an output directory was configured.
+func writeBatchReport(r *BatchResult, outputDir string) {
+	if outputDir == "" || r.Scan == nil || r.Hash == "" {
+		return
+	}
+
+	dir := filepath.Join(outputDir, r.Hash)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(r.Scan, "", "  ")
+	if err != nil {
+		return
+	}
+
+	os.WriteFile(filepath.Join(dir, "report.json"), data, 0644)
+}
@@ -0,0 +1,144 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultMaxDepth bounds how many carving hops RunAll will recurse into
+// artifacts a tool writes into OutputDir, so a binwalk dump that itself
+// contains a carvable image doesn't recurse forever.
+const defaultMaxDepth = 2
+
+// defaultMaxArtifacts bounds how many recursively-discovered files a single
+// top-level scan will ever re-scan, across the whole carving tree.
+const defaultMaxArtifacts = 200
+
+// defaultMaxRecurseDuration bounds how long after a top-level scan starts
+// it may keep taking on extracted artifacts; the root scan's own tool runs
+// count toward it.
+const defaultMaxRecurseDuration = 2 * time.Minute
+
+// carveBudget is shared by every recursive RunAll call spawned from one
+// top-level scan, so the artifact-count and wall-time limits apply to the
+// whole carving tree rather than resetting at each depth or branch.
+type carveBudget struct {
+	mu        sync.Mutex
+	deadline  time.Time
+	remaining int
+	seen      map[string]bool // sha256 of file contents already scanned
+}
+
+func newCarveBudget(maxArtifacts int, maxDuration time.Duration) *carveBudget {
+	return &carveBudget{
+		deadline:  time.Now().Add(maxDuration),
+		remaining: maxArtifacts,
+		seen:      make(map[string]bool),
+	}
+}
+
+// take claims one artifact slot for path, returning false if the budget is
+// exhausted, the deadline has passed, or path's content has already been
+// scanned (the cycle-detection case: a tool re-extracting the same bytes
+// it was handed).
+func (b *carveBudget) take(path string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remaining <= 0 || time.Now().After(b.deadline) {
+		return false
+	}
+
+	hash, err := hashFileContents(path)
+	if err != nil {
+		return false
+	}
+	if b.seen[hash] {
+		return false
+	}
+
+	b.seen[hash] = true
+	b.remaining--
+	return true
+}
+
+func hashFileContents(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// snapshotFiles returns the set of regular files currently under dir, used
+// to diff against what's there after a tool runs. Tools write extracted
+// artifacts anywhere under OutputDir (some into a tool-named subfolder,
+// some as a single file directly in it), so the snapshot covers the whole
+// tree rather than assuming a fixed layout.
+func snapshotFiles(dir string) map[string]bool {
+	seen := make(map[string]bool)
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			seen[path] = true
+		}
+		return nil
+	})
+	return seen
+}
+
+// discoverArtifacts returns files under dir present now but absent from
+// before, i.e. written since the snapshot was taken.
+//
+// Caveat: since tools sharing one OutputDir can run concurrently, a file
+// written by one tool in the window between another tool's before/after
+// snapshot can be misattributed to the wrong tool's provenance. This is
+// considered an acceptable tradeoff given the existing tools don't all use
+// distinct per-tool subfolders.
+func discoverArtifacts(dir string, before map[string]bool) []string {
+	var found []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && !before[path] {
+			found = append(found, path)
+		}
+		return nil
+	})
+	return found
+}
+
+// postExecArtifacts resolves a toolspec.Spec.PostExecGlob against outDir: if
+// it names a file, that file alone is returned; if it names a directory,
+// every regular file under it is returned; otherwise it's treated as a
+// filepath.Match pattern against outDir's immediate children. This is more
+// precise than the before/after snapshot diff when a tool tells us exactly
+// where its output lands, and avoids the diff's cross-tool misattribution.
+func postExecArtifacts(outDir, pattern string) []string {
+	full := filepath.Join(outDir, pattern)
+
+	if info, err := os.Stat(full); err == nil {
+		if !info.IsDir() {
+			return []string{full}
+		}
+		var found []string
+		filepath.Walk(full, func(path string, fi os.FileInfo, err error) error {
+			if err == nil && !fi.IsDir() {
+				found = append(found, path)
+			}
+			return nil
+		})
+		return found
+	}
+
+	matches, _ := filepath.Glob(full)
+	return matches
+}
@@ -3,7 +3,9 @@ package runner
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +15,26 @@ import (
 	"github.com/canyouhack/steg-cli/pkg/output"
 )
 
+// toolSem bounds the number of exec.Cmd processes running at once across
+// every concurrent RunAll call. Without it, a directory of a thousand files
+// scanned through BatchRun would fan out tens of thousands of processes at
+// the same time. SetToolConcurrency lets callers that fan out many RunAll
+// calls concurrently (e.g. BatchRun) size it once up front.
+var toolSem = make(chan struct{}, runtime.NumCPU()*4)
+
+// SetToolConcurrency resizes the shared tool-process semaphore. It is not
+// safe to call once scans are already in flight.
+func SetToolConcurrency(n int) {
+	if n <= 0 {
+		return
+	}
+	toolSem = make(chan struct{}, n)
+}
+
+// defaultPerToolTimeout bounds how long a single tool may run when neither
+// RunOpts.PerToolTimeout nor the tool's own override is set.
+const defaultPerToolTimeout = 120 * time.Second
+
 // RunOpts contains options for running tools
 type RunOpts struct {
 	Password  string
@@ -20,7 +42,34 @@ type RunOpts struct {
 	OutputDir string
 	Skip      []string
 	Only      []string
-	Timeout   time.Duration
+
+	// PerToolTimeout bounds how long a single tool may run before being
+	// canceled. 0 means defaultPerToolTimeout. A tool can override this
+	// for itself via Tool.Timeout (see pkg/toolspec's timeout_seconds).
+	PerToolTimeout time.Duration
+
+	// Concurrency bounds how many tools run at once within one RunAll
+	// call, including everything it recurses into. 0 means
+	// runtime.NumCPU(). Tools tagged Heavy are further limited to one
+	// running at a time regardless of this value; see scheduler.
+	Concurrency int
+
+	// Stream, if non-nil, receives each tool's *output.Result as soon as
+	// it completes rather than only once the whole scan is done. Used by
+	// --format json to show progress on long scans. RunAll sends
+	// to it but never closes it; all sends happen before RunAll returns.
+	Stream chan<- *output.Result
+
+	// Quiet suppresses the colored progress banner, for use with
+	// the machine-readable --format json/sarif modes that share stdout.
+	Quiet bool
+
+	// MaxDepth bounds how many carving hops RunAll will recurse into
+	// artifacts tools write into OutputDir (foremost, binwalk,
+	// stegoveritas, and friends). 0 means defaultMaxDepth. The file
+	// passed to RunAll is depth 0; each carving hop adds one, and
+	// artifacts found at depth MaxDepth are not scanned further.
+	MaxDepth int
 }
 
 // Tool represents a steganography analysis tool
@@ -30,6 +79,29 @@ type Tool struct {
 	Category       string // "general", "image", "audio"
 	SupportedTypes []detector.FileCategory
 	BuildCmd       func(filePath string, opts RunOpts) *exec.Cmd
+
+	// Applies, if set, decides applicability instead of SupportedTypes.
+	// Used by tools loaded from pkg/toolspec, which gate on extension/MIME
+	// globs rather than the detector.FileCategory enum.
+	Applies func(fi *detector.FileInfo) bool
+
+	// Timeout overrides opts.PerToolTimeout for this tool alone, if non-zero.
+	Timeout time.Duration
+
+	// Heavy marks a tool resource-intensive enough (stegseek and
+	// stegoveritas churning through a wordlist, foremost carving a large
+	// file) that the scheduler limits it to one running at a time instead
+	// of letting it compete for a full pool slot.
+	Heavy bool
+
+	// Env holds extra "KEY=VALUE" entries appended to the child process's
+	// environment, on top of the parent's.
+	Env []string
+
+	// PostExecGlob, if set, is a path (relative to OutputDir) RunAll uses
+	// to find this tool's carved artifacts instead of diffing OutputDir's
+	// contents before and after the tool ran. See postExecArtifacts.
+	PostExecGlob string
 }
 
 // ScanResult holds all results from a scan
@@ -39,37 +111,91 @@ type ScanResult struct {
 	Duration time.Duration
 }
 
-// RunAll runs all applicable tools concurrently on the given file
+// RunAll runs all applicable tools concurrently on the given file, then
+// recurses into whatever artifacts those tools carve out (foremost hits,
+// binwalk dumps, a cracked steghide payload, ...) up to opts.MaxDepth hops
+// deep. The returned ScanResult.Results is flat: carved results carry a
+// Provenance back to the tool and file that produced them, which is what
+// PrintToolResult uses to render the carving tree indented.
 func RunAll(fileInfo *detector.FileInfo, opts RunOpts) *ScanResult {
-	if opts.Timeout == 0 {
-		opts.Timeout = 60 * time.Second
+	if opts.PerToolTimeout == 0 {
+		opts.PerToolTimeout = defaultPerToolTimeout
+	}
+
+	maxDepth := opts.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = defaultMaxDepth
+	}
+	budget := newCarveBudget(defaultMaxArtifacts, defaultMaxRecurseDuration)
+	sched := newScheduler(opts.Concurrency)
+
+	var progress *output.ProgressView
+	if !opts.Quiet {
+		progress = output.NewProgressView()
+	}
+
+	startTime := time.Now()
+	results := runScanLevel(fileInfo, opts, nil, 0, maxDepth, budget, sched, progress)
+	totalDuration := time.Since(startTime)
+
+	if progress != nil {
+		progress.Finish()
 	}
 
+	return &ScanResult{
+		FileInfo: fileInfo,
+		Results:  results,
+		Duration: totalDuration,
+	}
+}
+
+// runScanLevel runs every tool applicable to fileInfo once, and for each
+// file a tool writes into OutputDir, detects its type and recurses into it
+// at depth+1 (unless maxDepth has been reached). prov records how fileInfo
+// itself was carved; nil for the top-level file passed to RunAll. sched and
+// progress are shared across the whole carving tree rooted at the top-level
+// RunAll call, not reset per recursion depth. The returned slice is flat,
+// with every result produced anywhere in that tree.
+func runScanLevel(fileInfo *detector.FileInfo, opts RunOpts, prov *output.Provenance, depth, maxDepth int, budget *carveBudget, sched *scheduler, progress *output.ProgressView) []*output.Result {
 	allTools := GetAllTools(opts)
 	applicable := filterTools(allTools, fileInfo, opts)
 
-	output.PrintScanStart(len(applicable))
+	if !opts.Quiet && depth == 0 {
+		output.PrintScanStart(len(applicable))
+	}
+
+	outDir := resolveOutputDir(opts)
 
 	results := make([]*output.Result, len(applicable))
+	var extra []*output.Result
+	var extraMu sync.Mutex
 	var wg sync.WaitGroup
 
-	startTime := time.Now()
-
 	for i, tool := range applicable {
 		wg.Add(1)
 		go func(idx int, t *Tool) {
 			defer wg.Done()
 
 			result := &output.Result{
-				ToolName: t.Name,
-				Category: t.Category,
+				ToolName:   t.Name,
+				Category:   t.Category,
+				File:       fileInfo.Path,
+				Provenance: prov,
 			}
 
+			// However this goroutine exits, record the result in its slot
+			// and, if the caller wants live progress, stream it out too.
+			defer func() {
+				results[idx] = result
+				if opts.Stream != nil {
+					opts.Stream <- result
+				}
+			}()
+
 			// Check if the binary exists
 			if !deps.IsToolAvailable(t.Binary) {
 				result.Skipped = true
 				result.SkipReason = fmt.Sprintf("%s not installed", t.Binary)
-				results[idx] = result
 				return
 			}
 
@@ -78,24 +204,60 @@ func RunAll(fileInfo *detector.FileInfo, opts RunOpts) *ScanResult {
 			if cmd == nil {
 				result.Skipped = true
 				result.SkipReason = "command not applicable"
-				results[idx] = result
 				return
 			}
 
-			// Run with timeout
-			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+			// Bound the number of processes actually running at once,
+			// independent of how many tool goroutines have been started:
+			// sched caps this scan's own pool (and gates Heavy tools to one
+			// at a time), toolSem caps the process as a whole across every
+			// concurrent RunAll call (e.g. from BatchRun).
+			release := sched.acquire(t)
+			toolSem <- struct{}{}
+			defer func() { <-toolSem; release() }()
+
+			// Only worth snapshotting OutputDir if we'd actually recurse
+			// into anything it finds there, and only if this tool doesn't
+			// already tell us exactly where its output lands.
+			var before map[string]bool
+			recurse := depth < maxDepth
+			if recurse && t.PostExecGlob == "" {
+				before = snapshotFiles(outDir)
+			}
+
+			// Run with timeout, using this tool's override if it has one
+			timeout := opts.PerToolTimeout
+			if t.Timeout > 0 {
+				timeout = t.Timeout
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
 			defer cancel()
 
 			cmd2 := exec.CommandContext(ctx, cmd.Path, cmd.Args[1:]...)
 			cmd2.Dir = cmd.Dir
 			cmd2.Env = cmd.Env
+			if len(t.Env) > 0 {
+				// cmd.Env is nil (no BuildCmd sets it), and exec.Cmd only
+				// auto-inherits the parent's environment when Env is nil at
+				// Start() time — so appending t.Env onto that nil slice
+				// would silently drop PATH/HOME/etc. and leave t.Env as the
+				// process's entire environment. Start from the real parent
+				// environment instead.
+				cmd2.Env = append(os.Environ(), t.Env...)
+			}
 
+			if progress != nil {
+				progress.Start(t.Name)
+			}
 			toolStart := time.Now()
 			out, err := cmd2.CombinedOutput()
 			result.Duration = time.Since(toolStart)
+			if progress != nil {
+				progress.Done(t.Name)
+			}
 
 			if ctx.Err() == context.DeadlineExceeded {
-				result.Error = fmt.Errorf("timeout after %s", opts.Timeout)
+				result.Error = fmt.Errorf("timeout after %s", timeout)
 			} else if err != nil {
 				// Some tools return non-zero exit codes even on success
 				outStr := strings.TrimSpace(string(out))
@@ -108,18 +270,38 @@ func RunAll(fileInfo *detector.FileInfo, opts RunOpts) *ScanResult {
 				result.Output = strings.TrimSpace(string(out))
 			}
 
-			results[idx] = result
+			if !recurse {
+				return
+			}
+
+			var artifacts []string
+			if t.PostExecGlob != "" {
+				artifacts = postExecArtifacts(outDir, t.PostExecGlob)
+			} else if before != nil {
+				artifacts = discoverArtifacts(outDir, before)
+			}
+
+			for _, path := range artifacts {
+				if !budget.take(path) {
+					continue
+				}
+				childInfo, err := detector.Detect(path)
+				if err != nil {
+					continue
+				}
+				childProv := &output.Provenance{ParentFile: fileInfo.Path, Tool: t.Name, Depth: depth + 1}
+				childResults := runScanLevel(childInfo, opts, childProv, depth+1, maxDepth, budget, sched, progress)
+
+				extraMu.Lock()
+				extra = append(extra, childResults...)
+				extraMu.Unlock()
+			}
 		}(i, tool)
 	}
 
 	wg.Wait()
-	totalDuration := time.Since(startTime)
 
-	return &ScanResult{
-		FileInfo: fileInfo,
-		Results:  results,
-		Duration: totalDuration,
-	}
+	return append(results, extra...)
 }
 
 // filterTools returns only tools that are applicable to the given file type
@@ -148,7 +330,11 @@ func filterTools(tools []*Tool, fileInfo *detector.FileInfo, opts RunOpts) []*To
 		}
 
 		// Check supported types
-		if len(tool.SupportedTypes) > 0 {
+		if tool.Applies != nil {
+			if !tool.Applies(fileInfo) {
+				continue
+			}
+		} else if len(tool.SupportedTypes) > 0 {
 			supported := false
 			for _, st := range tool.SupportedTypes {
 				if st == fileInfo.Category {
@@ -167,33 +353,12 @@ func filterTools(tools []*Tool, fileInfo *detector.FileInfo, opts RunOpts) []*To
 	return filtered
 }
 
-// PrintResults prints all results grouped by category
+// PrintResults prints all results grouped by category, via the same
+// PrettyReporter the scan/--format flag wiring uses.
 func PrintResults(scanResult *ScanResult) {
-	categories := []string{"general", "image", "audio", "text"}
-	categoryNames := map[string]string{
-		"general": "🔧 General Analysis",
-		"image":   "🖼️  Image Steganography",
-		"audio":   "🎵 Audio Steganography",
-		"text":    "📝 Text / Misc Steganography",
+	r := output.NewPrettyReporter()
+	for _, res := range scanResult.Results {
+		r.ToolResult(res)
 	}
-
-	for _, cat := range categories {
-		var catResults []*output.Result
-		for _, r := range scanResult.Results {
-			if r != nil && r.Category == cat {
-				catResults = append(catResults, r)
-			}
-		}
-
-		if len(catResults) == 0 {
-			continue
-		}
-
-		output.PrintCategoryHeader(categoryNames[cat])
-		for _, r := range catResults {
-			output.PrintToolResult(r)
-		}
-	}
-
-	output.PrintSummary(scanResult.Results, scanResult.Duration)
+	r.Summary(scanResult.Duration)
 }
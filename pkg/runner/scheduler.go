@@ -0,0 +1,41 @@
+package runner
+
+import "runtime"
+
+// heavyToolSem limits resource-heavy tools (stegseek, stegoveritas,
+// foremost — anything tagged Tool.Heavy) to one running at a time,
+// process-wide rather than per-scan, since two of these thrashing a disk or
+// CPU concurrently slows both down rather than saving any wall-clock.
+var heavyToolSem = make(chan struct{}, 1)
+
+// scheduler bounds how many tools run at once within a single RunAll call,
+// including everything it recurses into, per RunOpts.Concurrency.
+type scheduler struct {
+	sem chan struct{}
+}
+
+// newScheduler returns a scheduler sized to concurrency, or runtime.NumCPU()
+// if concurrency is 0 or negative.
+func newScheduler(concurrency int) *scheduler {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	return &scheduler{sem: make(chan struct{}, concurrency)}
+}
+
+// acquire blocks until a slot is free for t, taking the heavy-tool gate
+// first when t.Heavy is set so a heavy tool never occupies a pool slot
+// while queued behind another heavy tool. It returns a func to release
+// whatever it acquired.
+func (s *scheduler) acquire(t *Tool) func() {
+	if t.Heavy {
+		heavyToolSem <- struct{}{}
+	}
+	s.sem <- struct{}{}
+	return func() {
+		<-s.sem
+		if t.Heavy {
+			<-heavyToolSem
+		}
+	}
+}
@@ -5,348 +5,181 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/canyouhack/steg-cli/pkg/deps"
 	"github.com/canyouhack/steg-cli/pkg/detector"
+	"github.com/canyouhack/steg-cli/pkg/toolspec"
 )
 
 // allImageTypes is a convenience for tools that support most image types
 var allImageTypes = []detector.FileCategory{
 	detector.CategoryPNG, detector.CategoryJPG, detector.CategoryBMP,
 	detector.CategoryGIF, detector.CategoryTIFF, detector.CategoryWEBP,
+	detector.CategoryHEIC, detector.CategoryAVIF,
 }
 
 // allAudioTypes is a convenience for tools that support most audio types
 var allAudioTypes = []detector.FileCategory{
 	detector.CategoryWAV, detector.CategoryMP3, detector.CategoryFLAC,
 	detector.CategoryOGG, detector.CategoryAU,
+	detector.CategoryM4A, detector.CategoryWMA, detector.CategoryAAC,
+	detector.CategoryDSF, detector.CategoryDFF,
 }
 
 // allTypes combines all supported types
 var allTypes = append(append([]detector.FileCategory{}, allImageTypes...), allAudioTypes...)
 
-// GetAllTools returns all available tool definitions
-func GetAllTools(opts RunOpts) []*Tool {
-	outputDir := opts.OutputDir
-	if outputDir == "" {
-		outputDir = "/tmp/steg-cli-output"
+// selfBinary returns the path to the currently running executable, used by
+// tools (like bitplanes) that are implemented natively rather than by
+// shelling out to a separate program.
+func selfBinary() string {
+	if exe, err := os.Executable(); err == nil {
+		return exe
+	}
+	return os.Args[0]
+}
+
+// resolveOutputDir returns the directory tools write extracted artifacts
+// into, applying the same default GetAllTools uses when opts.OutputDir is
+// unset. Callers that need to inspect what a tool wrote (recursive carving)
+// must use this instead of opts.OutputDir directly, or they'll watch the
+// wrong directory whenever the caller didn't set one.
+func resolveOutputDir(opts RunOpts) string {
+	if opts.OutputDir == "" {
+		return "/tmp/steg-cli-output"
 	}
+	return opts.OutputDir
+}
+
+// GetAllTools returns all available tool definitions: the handful that need
+// real Go logic (nativeTools), plus everything declared in pkg/toolspec —
+// the embedded defaults merged with the user's ~/.config/steg-cli/tools.yaml
+// override. A spec marked disabled is left out entirely.
+func GetAllTools(opts RunOpts) []*Tool {
+	outputDir := resolveOutputDir(opts)
 	os.MkdirAll(outputDir, 0755)
 
-	return []*Tool{
-		// ========================
-		// GENERAL TOOLS
-		// ========================
-		{
-			Name:           "file",
-			Binary:         "file",
-			Category:       "general",
-			SupportedTypes: allTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("file", "-b", "--mime", fp)
-			},
-		},
-		{
-			Name:           "exiftool",
-			Binary:         "exiftool",
-			Category:       "general",
-			SupportedTypes: allTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("exiftool", fp)
-			},
-		},
-		{
-			Name:           "binwalk",
-			Binary:         "binwalk",
-			Category:       "general",
-			SupportedTypes: allTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("binwalk", fp)
-			},
-		},
-		{
-			Name:           "strings",
-			Binary:         "strings",
-			Category:       "general",
-			SupportedTypes: allTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("strings", "-n", "8", fp)
-			},
-		},
-		{
-			Name:           "hexdump",
-			Binary:         "xxd",
-			Category:       "general",
-			SupportedTypes: allTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("bash", "-c", fmt.Sprintf("xxd '%s' | head -50", fp))
-			},
-		},
-		{
-			Name:           "foremost",
-			Binary:         "foremost",
-			Category:       "general",
-			SupportedTypes: allTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				outDir := filepath.Join(outputDir, "foremost")
-				os.RemoveAll(outDir)
-				return exec.Command("foremost", "-t", "all", "-i", fp, "-o", outDir)
-			},
-		},
+	tools := nativeTools()
 
-		// ========================
-		// IMAGE TOOLS
-		// ========================
-		{
-			Name:     "zsteg",
-			Binary:   "zsteg",
-			Category: "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryPNG, detector.CategoryBMP,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("zsteg", fp, "--all")
-			},
-		},
-		{
-			Name:     "steghide-extract",
-			Binary:   "steghide",
-			Category: "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryJPG, detector.CategoryBMP,
-				detector.CategoryWAV, detector.CategoryAU,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				pass := opts.Password
-				if pass == "" {
-					pass = ""
-				}
-				outFile := filepath.Join(outputDir, "steghide_extracted.txt")
-				return exec.Command("steghide", "extract", "-sf", fp, "-p", pass, "-xf", outFile, "-f")
-			},
-		},
-		{
-			Name:     "steghide-info",
-			Binary:   "steghide",
-			Category: "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryJPG, detector.CategoryBMP,
-				detector.CategoryWAV, detector.CategoryAU,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				// Use -p "" to auto-answer passphrase prompt
-				return exec.Command("steghide", "info", fp, "-p", "")
-			},
-		},
-		{
-			Name:     "pngcheck",
-			Binary:   "pngcheck",
-			Category: "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryPNG,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("pngcheck", "-vtp", fp)
-			},
-		},
-		{
-			Name:           "identify",
-			Binary:         "gm",
-			Category:       "image",
-			SupportedTypes: allImageTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("gm", "identify", "-verbose", fp)
-			},
-		},
-		{
-			Name:     "jsteg",
-			Binary:   "jsteg",
-			Category: "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryJPG,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("jsteg", "reveal", fp)
-			},
-		},
-		{
-			Name:     "openstego",
-			Binary:   "openstego",
-			Category: "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryPNG,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				outFile := filepath.Join(outputDir, "openstego_extracted")
-				pass := opts.Password
-				args := []string{"extract", "--algorithm", "RandomLSB", "-sf", fp, "-xd", outFile}
-				if pass != "" {
-					args = append(args, "-p", pass)
-				}
-				return exec.Command("openstego", args...)
-			},
-		},
-		{
-			Name:     "stegoveritas",
-			Binary:   "stegoveritas",
-			Category: "image",
-			SupportedTypes: allImageTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				outDir := filepath.Join(outputDir, "stegoveritas")
-				os.RemoveAll(outDir)
-				os.MkdirAll(outDir, 0755)
-				return exec.Command("stegoveritas", "-out", outDir, fp)
-			},
-		},
-		{
-			Name:     "stegseek",
-			Binary:   "stegseek",
-			Category: "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryJPG, detector.CategoryBMP,
-				detector.CategoryWAV, detector.CategoryAU,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				rockyou := deps.RockyouPath()
+	specs, err := toolspec.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "steg-cli: loading tool definitions, falling back to built-ins: %v\n", err)
+		specs, err = toolspec.Defaults()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "steg-cli: loading built-in tool definitions: %v\n", err)
+			specs = nil
+		}
+	}
+
+	for _, spec := range specs {
+		if spec.Disabled {
+			continue
+		}
+		tools = append(tools, specTool(spec))
+	}
+
+	return tools
+}
+
+// specTool builds a *Tool from a declarative toolspec.Spec, rendering its
+// command template and handling the spec's output-directory bookkeeping
+// (clearing/creating a subfolder, resolving rockyou.txt) at exec time.
+func specTool(spec toolspec.Spec) *Tool {
+	var env []string
+	for k, v := range spec.Env {
+		env = append(env, k+"="+v)
+	}
+
+	return &Tool{
+		Name:     spec.Name,
+		Binary:   spec.Binary,
+		Category: spec.Category,
+		Applies: func(fi *detector.FileInfo) bool {
+			return spec.Applies(fi.Extension, fi.MimeType)
+		},
+		Timeout:      time.Duration(spec.TimeoutSeconds) * time.Second,
+		Heavy:        spec.Heavy,
+		Env:          env,
+		PostExecGlob: spec.PostExecGlob,
+		BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
+			outputDir := resolveOutputDir(opts)
+
+			var rockyou string
+			if spec.RequiresRockyou {
+				rockyou = deps.RockyouPath()
 				if _, err := os.Stat(rockyou); err != nil {
-					rockyou = deps.EnsureRockyouExists()
-					if rockyou == "" {
+					var ensureErr error
+					rockyou, ensureErr = deps.EnsureRockyouExists()
+					if ensureErr != nil {
+						fmt.Fprintf(os.Stderr, "steg-cli: %s: %v\n", spec.Name, ensureErr)
 						return nil
 					}
 				}
-				outFile := filepath.Join(outputDir, "stegseek_extracted.txt")
-				return exec.Command("stegseek", fp, rockyou, outFile, "--force")
-			},
-		},
-		{
-			Name:           "stegsolve",
-			Binary:         "python3",
-			Category:       "image",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryPNG, detector.CategoryBMP, detector.CategoryJPG,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				outDir := filepath.Join(outputDir, "stegsolve_planes")
-				os.MkdirAll(outDir, 0755)
-				script := fmt.Sprintf(`
-import sys, os
-try:
-    from PIL import Image
-except ImportError:
-    print("Pillow not installed. Run 'pip install Pillow'")
-    sys.exit(1)
+			}
 
-out_dir = '%s'
-img_path = '%s'
-try:
-    img = Image.open(img_path).convert('RGB')
-    width, height = img.size
-    
-    # We will avoid loading all pixels at once in python if possible,
-    # but load() is fast enough for typical steganography images.
-    pixels = img.load()
-    
-    channels = ['Red', 'Green', 'Blue']
-    for c in range(3):
-        for bit in range(8):
-            # Create a 1-bit image
-            out = Image.new('1', (width, height))
-            out_pixels = out.load()
-            for y in range(height):
-                for x in range(width):
-                    val = pixels[x, y][c]
-                    out_pixels[x, y] = (val >> bit) & 1
-            
-            plane_name = f"{channels[c]}_bit{bit}.png"
-            out.save(os.path.join(out_dir, plane_name))
-            
-    print(f"Extracted 24 RGB bitplanes to {out_dir}/")
-except Exception as e:
-    print(f"Error extracting bitplanes: {e}")
-`, outDir, fp)
-				return exec.Command("python3", "-c", script)
-			},
-		},
+			if spec.ClearOutputSubdir != "" {
+				os.RemoveAll(filepath.Join(outputDir, spec.ClearOutputSubdir))
+			}
+			if spec.CreateOutputSubdir != "" {
+				os.MkdirAll(filepath.Join(outputDir, spec.CreateOutputSubdir), 0755)
+			}
 
-		// ========================
-		// AUDIO TOOLS
-		// ========================
-		{
-			Name:     "steghide-audio",
-			Binary:   "steghide",
-			Category: "audio",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryWAV, detector.CategoryAU,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				pass := opts.Password
-				if pass == "" {
-					pass = ""
-				}
-				outFile := filepath.Join(outputDir, "steghide_audio_extracted.txt")
-				return exec.Command("steghide", "extract", "-sf", fp, "-p", pass, "-xf", outFile, "-f")
-			},
-		},
-		{
-			Name:     "steghide-audio-info",
-			Binary:   "steghide",
-			Category: "audio",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryWAV, detector.CategoryAU,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				return exec.Command("steghide", "info", fp, "-p", "")
-			},
-		},
-		{
-			Name:     "wavsteg",
-			Binary:   "stegolsb",
-			Category: "audio",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryWAV,
-			},
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				outFile := filepath.Join(outputDir, "wavsteg_extracted.txt")
-				return exec.Command("stegolsb", "wavsteg", "-r", "-i", fp, "-o", outFile, "-n", "2", "-b", "1000")
-			},
+			argv, err := spec.Render(toolspec.Vars{
+				File:      fp,
+				Password:  opts.Password,
+				OutputDir: outputDir,
+				Rockyou:   rockyou,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "steg-cli: %v\n", err)
+				return nil
+			}
+			return exec.Command(argv[0], argv[1:]...)
 		},
+	}
+}
+
+// nativeTools returns the tools that genuinely need Go logic rather than a
+// declarative command template: bitplanes shells out to this same binary's
+// __bitplanes subcommand (see cmd/bitplanes.go), polyglot likewise via
+// __polyglot (see cmd/polyglot.go), and unicode-steg's detection script is
+// too involved to template cleanly. Everything else lives in pkg/toolspec.
+func nativeTools() []*Tool {
+	return []*Tool{
 		{
-			Name:     "sox-spectrogram",
-			Binary:   "sox",
-			Category: "audio",
+			Name:     "bitplanes",
+			Binary:   selfBinary(),
+			Category: "image",
+			// BMP is deliberately excluded: pkg/imganalysis decodes via the
+			// standard library's image.Decode, which only has JPEG/PNG
+			// registered (there's no go.mod here to pull in
+			// golang.org/x/image/bmp), so a BMP here would just burn a
+			// scheduler slot on a guaranteed "decode image: unknown format".
 			SupportedTypes: []detector.FileCategory{
-				detector.CategoryWAV, detector.CategoryMP3, detector.CategoryFLAC,
-				detector.CategoryOGG,
+				detector.CategoryPNG, detector.CategoryJPG,
 			},
 			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				outFile := filepath.Join(outputDir, "spectrogram.png")
-				cmd := exec.Command("sox", fp, "-n", "spectrogram", "-o", outFile)
-				return cmd
+				outputDir := resolveOutputDir(opts)
+				outDir := filepath.Join(outputDir, "bitplanes")
+				os.MkdirAll(outDir, 0755)
+				return exec.Command(selfBinary(), "__bitplanes", fp, outDir)
 			},
 		},
 		{
-			Name:     "stegseek-audio",
-			Binary:   "stegseek",
-			Category: "audio",
-			SupportedTypes: []detector.FileCategory{
-				detector.CategoryWAV, detector.CategoryAU,
-			},
+			// No SupportedTypes/Applies: an appended archive can turn up
+			// behind any file, not just the PNG/JPEG/WAV formats this
+			// package knows how to bound the host end of.
+			Name:     "polyglot",
+			Binary:   selfBinary(),
+			Category: "general",
 			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				rockyou := deps.RockyouPath()
-				if _, err := os.Stat(rockyou); err != nil {
-					rockyou = deps.EnsureRockyouExists()
-					if rockyou == "" {
-						return nil
-					}
-				}
-				outFile := filepath.Join(outputDir, "stegseek_audio_extracted.txt")
-				return exec.Command("stegseek", fp, rockyou, outFile, "--force")
+				outputDir := resolveOutputDir(opts)
+				outDir := filepath.Join(outputDir, "polyglot")
+				os.MkdirAll(outDir, 0755)
+				return exec.Command(selfBinary(), "__polyglot", fp, outDir)
 			},
 		},
-
-		// ========================
-		// TEXT / MISC TOOLS
-		// ========================
 		{
 			Name:           "unicode-steg",
 			Binary:         "python3",
@@ -391,48 +224,6 @@ try:
         print("No zero-width Unicode steganography detected.")
 except Exception as e:
     print(f"Error: {e}")
-`, fp)
-				return exec.Command("python3", "-c", script)
-			},
-		},
-		{
-			Name:           "spammimic",
-			Binary:         "python3",
-			Category:       "text",
-			SupportedTypes: allTypes,
-			BuildCmd: func(fp string, opts RunOpts) *exec.Cmd {
-				// Detect spammimic-style encoded text
-				script := fmt.Sprintf(`
-import re
-try:
-    with open('%s', 'r', errors='ignore') as f:
-        data = f.read()
-    spam_indicators = [
-        'dear friend', 'make money', 'limited time offer', 'click here',
-        'act now', 'free', 'winner', 'congratulations', 'urgent',
-        'dear sir', 'opportunity', 'investment', 'discount',
-        'earn extra', 'no obligation', 'risk free', 'special promotion',
-        'be your own boss', 'work from home', 'double your',
-    ]
-    score = 0
-    matches = []
-    lower = data.lower()
-    for phrase in spam_indicators:
-        count = lower.count(phrase)
-        if count > 0:
-            score += count
-            matches.append(f"    '{phrase}': {count}x")
-    if score >= 3:
-        print("[!] SpamMimic-style steganography SUSPECTED!")
-        print(f"    Spam score: {score} (threshold: 3)")
-        print(f"    Matching phrases:")
-        for m in matches[:15]:
-            print(m)
-        print(f"\n    Try decoding at: https://www.spammimic.com/decode.shtml")
-    else:
-        print("No SpamMimic steganography patterns detected.")
-except Exception as e:
-    print(f"Error: {e}")
 `, fp)
 				return exec.Command("python3", "-c", script)
 			},
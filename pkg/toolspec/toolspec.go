@@ -0,0 +1,235 @@
+// Package toolspec defines the declarative schema for tool definitions: the
+// same general/image/audio/text tools that used to live as a hardcoded Go
+// slice in pkg/runner can instead be described as data, loaded from an
+// embedded default and a user override file. A handful of tools that
+// genuinely need Go logic (selfBinary invocations, inline scripts too
+// involved for a template) stay out of this package and are registered
+// natively by pkg/runner instead.
+package toolspec
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed defaults.yaml
+var defaultsFS embed.FS
+
+// Spec is one tool's declarative definition.
+type Spec struct {
+	Name     string `yaml:"name"`
+	Binary   string `yaml:"binary"`
+	Category string `yaml:"category"` // "general", "image", "audio", "text"
+
+	// Extensions and MimeGlobs gate which files this tool runs against.
+	// Extensions are compared case-insensitively against FileInfo.Extension
+	// (which includes the leading dot); "*" matches any file. MimeGlobs are
+	// filepath.Match patterns compared against FileInfo.MimeType. A spec
+	// with neither set applies to every file.
+	Extensions []string `yaml:"extensions,omitempty"`
+	MimeGlobs  []string `yaml:"mime_globs,omitempty"`
+
+	// Command is the argv to exec, one text/template per element. Each
+	// element is rendered against Vars and, if it renders to the empty
+	// string, dropped from the final argv — the idiom for an optional flag,
+	// e.g. two elements `{{if .Password}}-p{{end}}` and `{{.Password}}`
+	// both vanish together when no password was given.
+	Command []string `yaml:"command"`
+
+	Env            map[string]string `yaml:"env,omitempty"`
+	TimeoutSeconds int               `yaml:"timeout_seconds,omitempty"`
+
+	// Heavy marks a tool resource-intensive enough (a wordlist attack, a
+	// large-file carve) that the runner's scheduler limits it to one
+	// running at a time instead of letting it compete for a full pool slot.
+	Heavy bool `yaml:"heavy,omitempty"`
+
+	// PostExecGlob, if set, is a path relative to OutputDir identifying
+	// what this tool produces: a single file, a directory to collect
+	// recursively, or (failing either) a filepath.Match pattern against
+	// OutputDir's immediate children. Used for recursive carving. If
+	// unset, the runner falls back to diffing OutputDir's contents before
+	// and after the tool ran.
+	PostExecGlob string `yaml:"post_exec_glob,omitempty"`
+
+	// ClearOutputSubdir and CreateOutputSubdir are OutputDir-relative paths
+	// reset before the tool runs, for tools that refuse to write into a
+	// directory left over from a previous run (foremost) or that expect
+	// their output directory to already exist (stegoveritas). Clearing
+	// happens first; Create is a no-op if empty.
+	ClearOutputSubdir  string `yaml:"clear_output_subdir,omitempty"`
+	CreateOutputSubdir string `yaml:"create_output_subdir,omitempty"`
+
+	// RequiresRockyou marks a tool that needs the rockyou.txt wordlist
+	// (deps.RockyouPath / deps.EnsureRockyouExists) rather than a plain
+	// templated path, since it may need to be downloaded on first use.
+	RequiresRockyou bool `yaml:"requires_rockyou,omitempty"`
+
+	// Disabled lets a user turn off a built-in tool by name in their
+	// override file without deleting its definition.
+	Disabled bool `yaml:"disabled,omitempty"`
+}
+
+// File is the top-level shape of both defaults.yaml and the user's
+// ~/.config/steg-cli/tools.yaml.
+type File struct {
+	Tools []Spec `yaml:"tools"`
+}
+
+// Vars are the placeholders available to a Command template.
+type Vars struct {
+	File      string
+	Password  string
+	OutputDir string
+	Rockyou   string
+}
+
+// Applies reports whether this spec's Extensions/MimeGlobs match a file
+// with the given extension (including its leading dot) and MIME type.
+func (s Spec) Applies(ext, mimeType string) bool {
+	if len(s.Extensions) == 0 && len(s.MimeGlobs) == 0 {
+		return true
+	}
+	for _, e := range s.Extensions {
+		if e == "*" || strings.EqualFold(e, ext) {
+			return true
+		}
+	}
+	for _, pat := range s.MimeGlobs {
+		if ok, _ := filepath.Match(pat, mimeType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Render expands Command against vars, dropping any element that renders to
+// an empty string, and returns the resulting argv.
+func (s Spec) Render(vars Vars) ([]string, error) {
+	var argv []string
+	for _, raw := range s.Command {
+		tmpl, err := template.New(s.Name).Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("tool %s: bad command template %q: %w", s.Name, raw, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return nil, fmt.Errorf("tool %s: rendering command template %q: %w", s.Name, raw, err)
+		}
+		if arg := buf.String(); arg != "" {
+			argv = append(argv, arg)
+		}
+	}
+	if len(argv) == 0 {
+		return nil, fmt.Errorf("tool %s: command template rendered no arguments", s.Name)
+	}
+	return argv, nil
+}
+
+// Defaults returns the built-in tool specs embedded at build time.
+func Defaults() ([]Spec, error) {
+	data, err := defaultsFS.ReadFile("defaults.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("reading embedded defaults.yaml: %w", err)
+	}
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing embedded defaults.yaml: %w", err)
+	}
+	return f.Tools, nil
+}
+
+// ConfigPath returns the path to the user's tool override file.
+func ConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "steg-cli", "tools.yaml")
+}
+
+// Load returns the effective tool specs: the embedded defaults with the
+// user's ConfigPath() file merged on top. A missing override file is not an
+// error — it just means the defaults are used as-is.
+func Load() ([]Spec, error) {
+	specs, err := Defaults()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(ConfigPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return specs, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", ConfigPath(), err)
+	}
+
+	var user File
+	if err := yaml.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ConfigPath(), err)
+	}
+
+	return Merge(specs, user.Tools), nil
+}
+
+// Merge overlays override specs onto base by name: an override whose name
+// matches a base spec replaces it outright, and an override with a new name
+// is appended. Order of unmatched base specs is preserved.
+func Merge(base, overrides []Spec) []Spec {
+	merged := append([]Spec{}, base...)
+	index := make(map[string]int, len(merged))
+	for i, s := range merged {
+		index[s.Name] = i
+	}
+	for _, o := range overrides {
+		if i, ok := index[o.Name]; ok {
+			merged[i] = o
+		} else {
+			index[o.Name] = len(merged)
+			merged = append(merged, o)
+		}
+	}
+	return merged
+}
+
+// Append adds spec to the user's override file at ConfigPath(), creating the
+// file (and its parent directory) if it doesn't exist yet. An existing spec
+// with the same name is replaced.
+func Append(spec Spec) error {
+	path := ConfigPath()
+
+	var f File
+	if data, err := os.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	replaced := false
+	for i, s := range f.Tools {
+		if s.Name == spec.Name {
+			f.Tools[i] = spec
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		f.Tools = append(f.Tools, spec)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", path, err)
+	}
+	return os.WriteFile(path, data, 0644)
+}